@@ -0,0 +1,338 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+type Argument struct {
+	Name    string
+	Type    *Type
+	Indexed bool
+}
+
+type Method struct {
+	Name            string
+	Inputs          []Argument
+	Outputs         []Argument
+	StateMutability string
+}
+
+// ParseMethod parses a signature like "transfer(address,uint256)" or
+// "balanceOf(address owner) returns (uint256)".
+func ParseMethod(sig string) (*Method, error) {
+	name, inputs, rest, err := splitSig(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	m := Method{Name: name}
+	m.Inputs, err = parseArgs(inputs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rest = strings.TrimSpace(rest)
+	for _, mutability := range []string{"pure", "view", "payable", "nonpayable"} {
+		if trimmed, ok := strings.CutPrefix(rest, mutability); ok {
+			m.StateMutability = mutability
+			rest = strings.TrimSpace(trimmed)
+		}
+	}
+	if rest == "" {
+		return &m, nil
+	}
+
+	returns, ok := strings.CutPrefix(rest, "returns")
+	if !ok {
+		return nil, fmt.Errorf("abi: unexpected %q in %q", rest, sig)
+	}
+	returns = strings.TrimSpace(returns)
+	if !strings.HasPrefix(returns, "(") || !strings.HasSuffix(returns, ")") {
+		return nil, fmt.Errorf("abi: invalid returns in %q", sig)
+	}
+
+	m.Outputs, err = parseArgs(returns[1:len(returns)-1], false)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// MustParseMethod is like ParseMethod but panics on invalid input.
+func MustParseMethod(sig string) *Method {
+	m, err := ParseMethod(sig)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Sig returns the canonical signature, e.g. "transfer(address,uint256)".
+func (r *Method) Sig() string {
+	return sig(r.Name, r.Inputs)
+}
+
+func (r *Method) Selector() [4]byte {
+	var out [4]byte
+	copy(out[:], keccak256([]byte(r.Sig())))
+	return out
+}
+
+// Pack encodes a call of the method including its selector.
+func (r *Method) Pack(args ...any) ([]byte, error) {
+	encoded, err := Encode(r.Inputs, args...)
+	if err != nil {
+		return nil, fmt.Errorf("abi: packing %s: %w", r.Name, err)
+	}
+
+	selector := r.Selector()
+	return append(selector[:], encoded...), nil
+}
+
+// Unpack decodes the return data of the method.
+func (r *Method) Unpack(data []byte) ([]any, error) {
+	values, err := Decode(r.Outputs, data)
+	if err != nil {
+		return nil, fmt.Errorf("abi: unpacking %s: %w", r.Name, err)
+	}
+	return values, nil
+}
+
+// UnpackInput decodes call data of the method, selector included.
+func (r *Method) UnpackInput(data []byte) ([]any, error) {
+	selector := r.Selector()
+	if len(data) < 4 || [4]byte(data[:4]) != selector {
+		return nil, fmt.Errorf("abi: call data is not %s", r.Sig())
+	}
+
+	values, err := Decode(r.Inputs, data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("abi: unpacking input of %s: %w", r.Name, err)
+	}
+	return values, nil
+}
+
+type Event struct {
+	Name      string
+	Inputs    []Argument
+	Anonymous bool
+}
+
+// ParseEvent parses a signature like
+// "Transfer(address indexed from, address indexed to, uint256 value)".
+func ParseEvent(sig string) (*Event, error) {
+	name, inputs, rest, err := splitSig(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	e := Event{Name: name}
+	switch strings.TrimSpace(rest) {
+	case "":
+	case "anonymous":
+		e.Anonymous = true
+	default:
+		return nil, fmt.Errorf("abi: unexpected %q in %q", rest, sig)
+	}
+
+	e.Inputs, err = parseArgs(inputs, true)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// MustParseEvent is like ParseEvent but panics on invalid input.
+func MustParseEvent(sig string) *Event {
+	e, err := ParseEvent(sig)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (r *Event) Sig() string {
+	return sig(r.Name, r.Inputs)
+}
+
+// ID is the first topic of logs of the event.
+func (r *Event) ID() [32]byte {
+	return [32]byte(keccak256([]byte(r.Sig())))
+}
+
+// Error is a custom solidity error.
+type Error struct {
+	Name   string
+	Inputs []Argument
+}
+
+func (r *Error) Sig() string {
+	return sig(r.Name, r.Inputs)
+}
+
+func (r *Error) Selector() [4]byte {
+	var out [4]byte
+	copy(out[:], keccak256([]byte(r.Sig())))
+	return out
+}
+
+// ABI is the parsed json abi of a contract. Overloaded methods are keyed by
+// their signature, the first one also by its name.
+type ABI struct {
+	Methods map[string]*Method
+	Events  map[string]*Event
+	Errors  map[string]*Error
+}
+
+type jsonArgument struct {
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+	InternalType string         `json:"internalType"`
+	Indexed      bool           `json:"indexed"`
+	Components   []jsonArgument `json:"components"`
+}
+
+type jsonEntry struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Anonymous       bool           `json:"anonymous"`
+}
+
+// ParseJSON parses a json abi as produced by solc or tronscan.
+func ParseJSON(b []byte) (*ABI, error) {
+	var entries []jsonEntry
+	err := json.Unmarshal(b, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("abi: decoding json: %w", err)
+	}
+
+	out := ABI{
+		Methods: make(map[string]*Method),
+		Events:  make(map[string]*Event),
+		Errors:  make(map[string]*Error),
+	}
+	for _, e := range entries {
+		inputs, err := jsonArgs(e.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("abi: %s: %w", e.Name, err)
+		}
+		outputs, err := jsonArgs(e.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("abi: %s: %w", e.Name, err)
+		}
+
+		// tronscan capitalizes types
+		switch strings.ToLower(e.Type) {
+		case "function", "":
+			m := &Method{Name: e.Name, Inputs: inputs, Outputs: outputs, StateMutability: e.StateMutability}
+			out.Methods[m.Sig()] = m
+			if _, ok := out.Methods[m.Name]; !ok {
+				out.Methods[m.Name] = m
+			}
+		case "event":
+			ev := &Event{Name: e.Name, Inputs: inputs, Anonymous: e.Anonymous}
+			out.Events[ev.Sig()] = ev
+			if _, ok := out.Events[ev.Name]; !ok {
+				out.Events[ev.Name] = ev
+			}
+		case "error":
+			er := &Error{Name: e.Name, Inputs: inputs}
+			out.Errors[er.Sig()] = er
+			if _, ok := out.Errors[er.Name]; !ok {
+				out.Errors[er.Name] = er
+			}
+		}
+	}
+	return &out, nil
+}
+
+func jsonArgs(in []jsonArgument) ([]Argument, error) {
+	out := make([]Argument, len(in))
+	for i, a := range in {
+		typ, err := jsonType(a)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = Argument{Name: a.Name, Type: typ, Indexed: a.Indexed}
+	}
+	return out, nil
+}
+
+func jsonType(a jsonArgument) (*Type, error) {
+	if !strings.HasPrefix(a.Type, "tuple") {
+		return ParseType(a.Type)
+	}
+
+	components, err := jsonArgs(a.Components)
+	if err != nil {
+		return nil, err
+	}
+	tuple := NewTuple(components)
+
+	// "tuple[2][]" becomes "(...)[2][]"
+	suffix := strings.TrimPrefix(a.Type, "tuple")
+	if suffix == "" {
+		return tuple, nil
+	}
+
+	typ, err := ParseType(tuple.name + suffix)
+	if err != nil {
+		return nil, err
+	}
+
+	// reparsing lost the component names, put the tuple back in
+	inner := typ
+	for inner.Elem != nil && inner.Elem.Kind != TupleKind {
+		inner = inner.Elem
+	}
+	inner.Elem = tuple
+	return typ, nil
+}
+
+func splitSig(sig string) (string, string, string, error) {
+	sig = strings.TrimSpace(sig)
+	sig = strings.TrimPrefix(sig, "function ")
+	sig = strings.TrimPrefix(sig, "event ")
+
+	open := strings.IndexByte(sig, '(')
+	if open < 1 {
+		return "", "", "", fmt.Errorf("abi: invalid signature %q", sig)
+	}
+
+	depth := 0
+	for i := open; i < len(sig); i++ {
+		switch sig[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(sig[:open]), sig[open+1 : i], sig[i+1:], nil
+			}
+		}
+	}
+	return "", "", "", fmt.Errorf("abi: unbalanced parentheses in %q", sig)
+}
+
+func sig(name string, args []Argument) string {
+	types := make([]string, len(args))
+	for i, a := range args {
+		types[i] = a.Type.name
+	}
+	return name + "(" + strings.Join(types, ",") + ")"
+}
+
+func keccak256(data ...[]byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hasher.Write(d)
+	}
+	return hasher.Sum(nil)
+}
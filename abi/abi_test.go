@@ -0,0 +1,250 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func words(s ...string) string {
+	return strings.Join(s, "")
+}
+
+func TestSelectors(t *testing.T) {
+	t.Parallel()
+
+	transfer := MustParseMethod("transfer(address to, uint256 value) returns (bool)")
+	assert.Equal(t, "transfer(address,uint256)", transfer.Sig())
+	selector := transfer.Selector()
+	assert.Equal(t, "a9059cbb", hex.EncodeToString(selector[:]))
+
+	balanceOf := MustParseMethod("function balanceOf(address) view returns (uint256)")
+	selector = balanceOf.Selector()
+	assert.Equal(t, "70a08231", hex.EncodeToString(selector[:]))
+	assert.Equal(t, "view", balanceOf.StateMutability)
+	assert.Len(t, balanceOf.Outputs, 1)
+
+	event := MustParseEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+	id := event.ID()
+	assert.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hex.EncodeToString(id[:]))
+	assert.True(t, event.Inputs[0].Indexed)
+	assert.False(t, event.Inputs[2].Indexed)
+}
+
+// Examples from the solidity abi specification.
+func TestSpecExamples(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		sig  string
+		args []any
+		want string
+	}{
+		{
+			sig:  "baz(uint32,bool)",
+			args: []any{69, true},
+			want: words(
+				"cdcd77c0",
+				"0000000000000000000000000000000000000000000000000000000000000045",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			),
+		},
+		{
+			sig:  "sam(bytes,bool,uint256[])",
+			args: []any{[]byte("dave"), true, []any{1, 2, 3}},
+			want: words(
+				"a5643bf2",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000004",
+				"6461766500000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+			),
+		},
+		{
+			sig:  "f(uint256,uint32[],bytes10,bytes)",
+			args: []any{0x123, []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			want: words(
+				"8be65246",
+				"0000000000000000000000000000000000000000000000000000000000000123",
+				"0000000000000000000000000000000000000000000000000000000000000080",
+				"3132333435363738393000000000000000000000000000000000000000000000",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000456",
+				"0000000000000000000000000000000000000000000000000000000000000789",
+				"000000000000000000000000000000000000000000000000000000000000000d",
+				"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+			),
+		},
+		{
+			sig:  "g(uint256[][],string[])",
+			args: []any{[]any{[]any{1, 2}, []any{3}}, []string{"one", "two", "three"}},
+			want: words(
+				"2289b18c",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"0000000000000000000000000000000000000000000000000000000000000140",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"6f6e650000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"74776f0000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000005",
+				"7468726565000000000000000000000000000000000000000000000000000000",
+			),
+		},
+	}
+
+	for _, c := range cases {
+		m := MustParseMethod(c.sig)
+		packed, err := m.Pack(c.args...)
+		assert.NoError(t, err, c.sig)
+		assert.Equal(t, c.want, hex.EncodeToString(packed), c.sig)
+
+		// decoding and encoding again must give the same bytes
+		values, err := m.UnpackInput(packed)
+		assert.NoError(t, err, c.sig)
+		repacked, err := m.Pack(values...)
+		assert.NoError(t, err, c.sig)
+		assert.Equal(t, packed, repacked, c.sig)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	usdt := tronaddr.MustParse("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	huge, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+
+	args := []Argument{
+		{Name: "addr", Type: MustParseType("address")},
+		{Name: "amount", Type: MustParseType("uint256")},
+		{Name: "delta", Type: MustParseType("int8")},
+		{Name: "pair", Type: MustParseType("(string,bytes4)")},
+	}
+	encoded, err := Encode(args, usdt, huge, -5, []any{"memo", "0xdeadbeef"})
+	assert.NoError(t, err)
+
+	values, err := DecodeMap(args, encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, usdt, values["addr"])
+	assert.Equal(t, huge, values["amount"])
+	assert.Equal(t, big.NewInt(-5), values["delta"])
+	assert.Equal(t, []any{"memo", []byte{0xde, 0xad, 0xbe, 0xef}}, values["pair"])
+
+	_, err = Decode(args, encoded[:len(encoded)-33])
+	assert.Error(t, err)
+
+	_, err = Encode(args[2:3], 128)
+	assert.Error(t, err)
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	a, err := ParseJSON([]byte(`[
+		{"type": "function", "name": "submit", "stateMutability": "nonpayable",
+			"inputs": [{"name": "orders", "type": "tuple[]", "components": [
+				{"name": "maker", "type": "address"},
+				{"name": "amount", "type": "uint256"}
+			]}],
+			"outputs": []},
+		{"type": "event", "name": "Approval", "anonymous": false, "inputs": [
+			{"name": "owner", "type": "address", "indexed": true},
+			{"name": "spender", "type": "address", "indexed": true},
+			{"name": "value", "type": "uint256", "indexed": false}
+		]},
+		{"type": "error", "name": "InsufficientBalance", "inputs": [
+			{"name": "available", "type": "uint256"},
+			{"name": "required", "type": "uint256"}
+		]}
+	]`))
+	assert.NoError(t, err)
+
+	submit := a.Methods["submit"]
+	assert.Equal(t, "submit((address,uint256)[])", submit.Sig())
+	assert.Equal(t, "maker", submit.Inputs[0].Type.Elem.Components[0].Name)
+
+	_, err = submit.Pack([]any{map[string]any{"maker": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "amount": 1}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Approval(address,address,uint256)", a.Events["Approval"].Sig())
+
+	revert := a.Errors["InsufficientBalance"].Selector()
+	data, _ := Encode(a.Errors["InsufficientBalance"].Inputs, 1, 2)
+	reason, err := DecodeRevert(append(revert[:], data...), a)
+	assert.NoError(t, err)
+	assert.Equal(t, "InsufficientBalance(1, 2)", reason)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	t.Parallel()
+
+	data, _ := hex.DecodeString(words(
+		"08c379a0",
+		"0000000000000000000000000000000000000000000000000000000000000020",
+		"000000000000000000000000000000000000000000000000000000000000001a",
+		"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000",
+	))
+	reason, err := DecodeRevert(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Not enough Ether provided.", reason)
+
+	data, _ = hex.DecodeString(words(
+		"4e487b71",
+		"0000000000000000000000000000000000000000000000000000000000000011",
+	))
+	reason, err = DecodeRevert(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "panic 0x11: arithmetic overflow", reason)
+
+	_, err = DecodeRevert(nil, nil)
+	assert.ErrorIs(t, err, ErrNoReason)
+}
+
+func TestDecodeLog(t *testing.T) {
+	t.Parallel()
+
+	event := MustParseEvent("Deposit(address indexed from, string indexed memo, uint256 amount, string note)")
+	id := event.ID()
+
+	from := tronaddr.MustParse("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	fromTopic := make([]byte, 32)
+	copy(fromTopic[12:], from.EVMBytes())
+	memoTopic := keccak256([]byte("memo"))
+
+	data, err := Encode([]Argument{
+		{Type: MustParseType("uint256")},
+		{Type: MustParseType("string")},
+	}, 42, "hello")
+	assert.NoError(t, err)
+
+	args, err := event.DecodeLog([][]byte{id[:], fromTopic, memoTopic}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, from, args["from"])
+	assert.Equal(t, memoTopic, args["memo"])
+	assert.Equal(t, big.NewInt(42), args["amount"])
+	assert.Equal(t, "hello", args["note"])
+
+	_, err = event.DecodeLog([][]byte{id[:], fromTopic}, data)
+	assert.Error(t, err)
+}
@@ -0,0 +1,209 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/joshuayildiz/wallet/tronaddr"
+)
+
+var ErrShortData = errors.New("abi: data too short")
+
+// Decode decodes data encoded as a tuple of args.
+//
+// Integers decode to *big.Int, addresses to tronaddr.Address, fixed and
+// dynamic bytes to []byte, arrays and tuples to []any.
+func Decode(args []Argument, data []byte) ([]any, error) {
+	types := make([]*Type, len(args))
+	for i, a := range args {
+		types[i] = a.Type
+	}
+	return decodeTuple(types, data)
+}
+
+// DecodeMap is like Decode but keys the values by argument name.
+func DecodeMap(args []Argument, data []byte) (map[string]any, error) {
+	values, err := Decode(args, data)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]any, len(args))
+	for i, a := range args {
+		out[a.Name] = values[i]
+	}
+	return out, nil
+}
+
+func decodeTuple(types []*Type, data []byte) ([]any, error) {
+	out := make([]any, len(types))
+	pos := 0
+	for i, t := range types {
+		if t.Dynamic() {
+			offset, err := readSize(data, pos)
+			if err != nil {
+				return nil, err
+			}
+			if offset > len(data) {
+				return nil, fmt.Errorf("%w: offset %d out of range", ErrShortData, offset)
+			}
+
+			out[i], err = decodeValue(t, data[offset:])
+			if err != nil {
+				return nil, err
+			}
+			pos += 32
+			continue
+		}
+
+		if pos > len(data) {
+			return nil, ErrShortData
+		}
+		var err error
+		out[i], err = decodeValue(t, data[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += t.headSize()
+	}
+	return out, nil
+}
+
+// decodeValue decodes a value starting at the beginning of data.
+func decodeValue(t *Type, data []byte) (any, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return decodeInt(word, t.Kind == IntKind, t.Size)
+
+	case AddressKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !allZero(word[:12]) {
+			return nil, fmt.Errorf("abi: address %x has dirty padding", word)
+		}
+		return tronaddr.FromBytes(word[12:])
+
+	case BoolKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !allZero(word[:31]) || word[31] > 1 {
+			return nil, fmt.Errorf("abi: invalid bool %x", word)
+		}
+		return word[31] == 1, nil
+
+	case FixedBytesKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.Clone(word[:t.Size]), nil
+
+	case BytesKind, StringKind:
+		size, err := readSize(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if 32+size > len(data) {
+			return nil, fmt.Errorf("%w: need %d bytes", ErrShortData, size)
+		}
+
+		b := bytes.Clone(data[32 : 32+size])
+		if t.Kind == StringKind {
+			return string(b), nil
+		}
+		return b, nil
+
+	case SliceKind, ArrayKind:
+		n := t.Size
+		if t.Kind == SliceKind {
+			var err error
+			n, err = readSize(data, 0)
+			if err != nil {
+				return nil, err
+			}
+			data = data[32:]
+
+			// every item takes up at least a word, this keeps a hostile length
+			// from allocating huge slices
+			if n > len(data)/32 {
+				return nil, fmt.Errorf("%w: slice of %d items", ErrShortData, n)
+			}
+		}
+
+		types := make([]*Type, n)
+		for i := range types {
+			types[i] = t.Elem
+		}
+		return decodeTuple(types, data)
+
+	case TupleKind:
+		types := make([]*Type, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type
+		}
+		return decodeTuple(types, data)
+	}
+
+	return nil, fmt.Errorf("abi: unknown kind %d", t.Kind)
+}
+
+func decodeInt(word []byte, signed bool, bits int) (*big.Int, error) {
+	n := new(big.Int).SetBytes(word)
+	if signed && word[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		limit.Rsh(limit, 1)
+		if n.Cmp(new(big.Int).Neg(limit)) < 0 || n.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("abi: %s does not fit int%d", n, bits)
+		}
+	} else if n.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("abi: %s does not fit uint%d", n, bits)
+	}
+	return n, nil
+}
+
+func readWord(data []byte, pos int) ([]byte, error) {
+	if pos < 0 || pos+32 > len(data) {
+		return nil, ErrShortData
+	}
+	return data[pos : pos+32], nil
+}
+
+// readSize reads a length or offset, which must fit into an int.
+func readSize(data []byte, pos int) (int, error) {
+	word, err := readWord(data, pos)
+	if err != nil {
+		return 0, err
+	}
+	if !allZero(word[:24]) {
+		return 0, fmt.Errorf("abi: size %x too large", word)
+	}
+
+	n := new(big.Int).SetBytes(word[24:])
+	if !n.IsInt64() || n.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("%w: size %s out of range", ErrShortData, n)
+	}
+	return int(n.Int64()), nil
+}
+
+func allZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/joshuayildiz/wallet/tronaddr"
+)
+
+// Encode encodes values as a tuple of args, i.e. the way call arguments and
+// return values are encoded.
+//
+// Accepted values are *big.Int, any go integer or a decimal string for
+// integers, tronaddr.Address or any address string for addresses, []byte or
+// hex strings for bytes, and slices for arrays and tuples. Tuples may also be
+// given as map[string]any keyed by component name.
+func Encode(args []Argument, values ...any) ([]byte, error) {
+	if len(args) != len(values) {
+		return nil, fmt.Errorf("expected %d values, got %d", len(args), len(values))
+	}
+
+	types := make([]*Type, len(args))
+	for i, a := range args {
+		types[i] = a.Type
+	}
+	return encodeTuple(types, values)
+}
+
+func encodeTuple(types []*Type, values []any) ([]byte, error) {
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+	for i, t := range types {
+		encoded, err := encodeValue(t, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("value %d (%s): %w", i, t, err)
+		}
+
+		if t.Dynamic() {
+			head = append(head, encodeUint(uint64(headSize+len(tail)))...)
+			tail = append(tail, encoded...)
+		} else {
+			head = append(head, encoded...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+func encodeValue(t *Type, v any) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		n, err := toBig(v)
+		if err != nil {
+			return nil, err
+		}
+		return encodeInt(n, t.Kind == IntKind, t.Size)
+
+	case AddressKind:
+		addr, err := toAddr(v)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, 32)
+		copy(out[12:], addr.EVMBytes())
+		return out, nil
+
+	case BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", v)
+		}
+		out := make([]byte, 32)
+		if b {
+			out[31] = 1
+		}
+		return out, nil
+
+	case FixedBytesKind:
+		b, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > t.Size {
+			return nil, fmt.Errorf("expected at most %d bytes, got %d", t.Size, len(b))
+		}
+		out := make([]byte, 32)
+		copy(out, b)
+		return out, nil
+
+	case BytesKind:
+		b, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return encodeDynBytes(b), nil
+
+	case StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", v)
+		}
+		return encodeDynBytes([]byte(s)), nil
+
+	case SliceKind, ArrayKind:
+		items, err := toSlice(v)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == ArrayKind && len(items) != t.Size {
+			return nil, fmt.Errorf("expected %d items, got %d", t.Size, len(items))
+		}
+
+		types := make([]*Type, len(items))
+		for i := range types {
+			types[i] = t.Elem
+		}
+		encoded, err := encodeTuple(types, items)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind == SliceKind {
+			return append(encodeUint(uint64(len(items))), encoded...), nil
+		}
+		return encoded, nil
+
+	case TupleKind:
+		var items []any
+		if m, ok := v.(map[string]any); ok {
+			items = make([]any, len(t.Components))
+			for i, c := range t.Components {
+				item, ok := m[c.Name]
+				if !ok {
+					return nil, fmt.Errorf("tuple is missing %q", c.Name)
+				}
+				items[i] = item
+			}
+		} else {
+			var err error
+			items, err = toSlice(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(items) != len(t.Components) {
+			return nil, fmt.Errorf("expected %d tuple items, got %d", len(t.Components), len(items))
+		}
+
+		types := make([]*Type, len(items))
+		for i, c := range t.Components {
+			types[i] = c.Type
+		}
+		return encodeTuple(types, items)
+	}
+
+	return nil, fmt.Errorf("unknown kind %d", t.Kind)
+}
+
+func encodeInt(n *big.Int, signed bool, bits int) ([]byte, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		limit.Rsh(limit, 1)
+		min := new(big.Int).Neg(limit)
+		if n.Cmp(min) < 0 || n.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("%s does not fit int%d", n, bits)
+		}
+	} else if n.Sign() < 0 || n.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%s does not fit uint%d", n, bits)
+	}
+
+	// two's complement for negative values
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	out := make([]byte, 32)
+	n.FillBytes(out)
+	return out, nil
+}
+
+func encodeUint(v uint64) []byte {
+	out := make([]byte, 32)
+	new(big.Int).SetUint64(v).FillBytes(out)
+	return out
+}
+
+func encodeDynBytes(b []byte) []byte {
+	padded := (len(b) + 31) / 32 * 32
+	out := make([]byte, 32+padded)
+	copy(out, encodeUint(uint64(len(b))))
+	copy(out[32:], b)
+	return out
+}
+
+func toBig(v any) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case string:
+		n, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("%q is not an integer", v)
+		}
+		return n, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", v)
+}
+
+func toAddr(v any) (tronaddr.Address, error) {
+	switch v := v.(type) {
+	case tronaddr.Address:
+		return v, nil
+	case *tronaddr.Address:
+		return *v, nil
+	case string:
+		return tronaddr.Parse(v)
+	case []byte:
+		return tronaddr.FromBytes(v)
+	}
+	return tronaddr.Address{}, fmt.Errorf("expected address, got %T", v)
+}
+
+func toBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		b, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not hex", v)
+		}
+		return b, nil
+	}
+
+	// fixed size arrays like [32]byte
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		out := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(out), rv)
+		return out, nil
+	}
+	return nil, fmt.Errorf("expected bytes, got %T", v)
+}
+
+func toSlice(v any) ([]any, error) {
+	if items, ok := v.([]any); ok {
+		return items, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected slice, got %T", v)
+	}
+
+	out := make([]any, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// DecodeLog decodes the topics and data of a log of the event into named
+// arguments. Indexed arguments of dynamic types only have their keccak256
+// hash in the topics, so they decode to that hash as []byte.
+func (r *Event) DecodeLog(topics [][]byte, data []byte) (map[string]any, error) {
+	if !r.Anonymous {
+		id := r.ID()
+		if len(topics) == 0 || !bytes.Equal(topics[0], id[:]) {
+			return nil, fmt.Errorf("abi: log is not %s", r.Sig())
+		}
+		topics = topics[1:]
+	}
+
+	var indexed, nonIndexed []Argument
+	for _, a := range r.Inputs {
+		if a.Indexed {
+			indexed = append(indexed, a)
+		} else {
+			nonIndexed = append(nonIndexed, a)
+		}
+	}
+	if len(topics) != len(indexed) {
+		return nil, fmt.Errorf("abi: %s expects %d indexed topics, got %d", r.Name, len(indexed), len(topics))
+	}
+
+	out, err := DecodeMap(nonIndexed, data)
+	if err != nil {
+		return nil, fmt.Errorf("abi: decoding %s data: %w", r.Name, err)
+	}
+
+	for i, a := range indexed {
+		if len(topics[i]) != 32 {
+			return nil, fmt.Errorf("abi: topic %d of %s has %d bytes", i, r.Name, len(topics[i]))
+		}
+
+		switch a.Type.Kind {
+		case BytesKind, StringKind, SliceKind, ArrayKind, TupleKind:
+			out[a.Name] = bytes.Clone(topics[i])
+		default:
+			v, err := decodeValue(a.Type, topics[i])
+			if err != nil {
+				return nil, fmt.Errorf("abi: decoding %s topic %s: %w", r.Name, a.Name, err)
+			}
+			out[a.Name] = v
+		}
+	}
+
+	return out, nil
+}
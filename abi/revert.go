@@ -0,0 +1,83 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	errorMethod = MustParseMethod("Error(string)")
+	panicMethod = MustParseMethod("Panic(uint256)")
+)
+
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert failed",
+	0x11: "arithmetic overflow",
+	0x12: "division by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero function",
+}
+
+var ErrNoReason = errors.New("abi: revert without reason")
+
+// DecodeRevert turns the return data of a reverted call into a readable
+// reason. Custom errors are only known if they are in a.
+func DecodeRevert(data []byte, a *ABI) (string, error) {
+	if len(data) == 0 {
+		return "", ErrNoReason
+	}
+	if len(data) < 4 {
+		return "", fmt.Errorf("abi: revert data %x too short", data)
+	}
+	selector := [4]byte(data[:4])
+
+	switch selector {
+	case errorMethod.Selector():
+		values, err := Decode(errorMethod.Inputs, data[4:])
+		if err != nil {
+			return "", err
+		}
+		return values[0].(string), nil
+
+	case panicMethod.Selector():
+		values, err := Decode(panicMethod.Inputs, data[4:])
+		if err != nil {
+			return "", err
+		}
+
+		code := values[0].(*big.Int)
+		reason, ok := panicReasons[code.Uint64()]
+		if !code.IsUint64() || !ok {
+			reason = "unknown"
+		}
+		return fmt.Sprintf("panic 0x%x: %s", code, reason), nil
+	}
+
+	if a != nil {
+		for _, e := range a.Errors {
+			if e.Selector() != selector {
+				continue
+			}
+
+			values, err := Decode(e.Inputs, data[4:])
+			if err != nil {
+				return "", err
+			}
+
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = fmt.Sprint(v)
+			}
+			return e.Name + "(" + strings.Join(parts, ", ") + ")", nil
+		}
+	}
+
+	return "", fmt.Errorf("abi: unknown revert selector %x", selector)
+}
@@ -0,0 +1,273 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Kind int
+
+const (
+	UintKind Kind = iota
+	IntKind
+	AddressKind
+	BoolKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	SliceKind
+	ArrayKind
+	TupleKind
+)
+
+// Type is a solidity type.
+type Type struct {
+	Kind Kind
+
+	// Bits for ints, length for fixed bytes and arrays.
+	Size int
+
+	// Element type of slices and arrays.
+	Elem *Type
+
+	// Components of tuples.
+	Components []Argument
+
+	// Canonical name as used in signatures.
+	name string
+}
+
+// ParseType parses a canonical type like "uint256", "address[]" or
+// "(uint256,bytes)[2]". Named tuples are only available through json abis.
+func ParseType(s string) (*Type, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("abi: empty type")
+	}
+
+	// arrays are parsed from the outside in, so "uint8[2][]" is a slice of
+	// uint8[2]
+	if strings.HasSuffix(s, "]") {
+		open := strings.LastIndexByte(s, '[')
+		if open < 0 {
+			return nil, fmt.Errorf("abi: invalid type %q", s)
+		}
+
+		elem, err := ParseType(s[:open])
+		if err != nil {
+			return nil, err
+		}
+
+		size := s[open+1 : len(s)-1]
+		if size == "" {
+			return &Type{Kind: SliceKind, Elem: elem, name: elem.name + "[]"}, nil
+		}
+
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("abi: invalid array size in %q", s)
+		}
+		return &Type{Kind: ArrayKind, Size: n, Elem: elem, name: elem.name + "[" + size + "]"}, nil
+	}
+
+	if strings.HasPrefix(s, "(") {
+		if !strings.HasSuffix(s, ")") {
+			return nil, fmt.Errorf("abi: invalid tuple %q", s)
+		}
+
+		args, err := parseArgs(s[1:len(s)-1], false)
+		if err != nil {
+			return nil, err
+		}
+		return NewTuple(args), nil
+	}
+
+	switch {
+	case s == "address":
+		return &Type{Kind: AddressKind, name: s}, nil
+	case s == "bool":
+		return &Type{Kind: BoolKind, name: s}, nil
+	case s == "string":
+		return &Type{Kind: StringKind, name: s}, nil
+	case s == "bytes":
+		return &Type{Kind: BytesKind, name: s}, nil
+	case s == "trcToken":
+		// tron token id, encoded like uint256
+		return &Type{Kind: UintKind, Size: 256, name: s}, nil
+
+	case strings.HasPrefix(s, "bytes"):
+		n, err := strconv.Atoi(s[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("abi: invalid type %q", s)
+		}
+		return &Type{Kind: FixedBytesKind, Size: n, name: s}, nil
+
+	case strings.HasPrefix(s, "uint"), strings.HasPrefix(s, "int"):
+		kind := IntKind
+		suffix := strings.TrimPrefix(s, "int")
+		if strings.HasPrefix(s, "uint") {
+			kind = UintKind
+			suffix = strings.TrimPrefix(s, "uint")
+		}
+
+		bits := 256
+		if suffix != "" {
+			var err error
+			bits, err = strconv.Atoi(suffix)
+			if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+				return nil, fmt.Errorf("abi: invalid type %q", s)
+			}
+		}
+
+		name := "int" + strconv.Itoa(bits)
+		if kind == UintKind {
+			name = "u" + name
+		}
+		return &Type{Kind: kind, Size: bits, name: name}, nil
+	}
+
+	return nil, fmt.Errorf("abi: unknown type %q", s)
+}
+
+// MustParseType is like ParseType but panics on invalid input.
+func MustParseType(s string) *Type {
+	t, err := ParseType(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func NewTuple(components []Argument) *Type {
+	names := make([]string, len(components))
+	for i, c := range components {
+		names[i] = c.Type.name
+	}
+	return &Type{
+		Kind:       TupleKind,
+		Components: components,
+		name:       "(" + strings.Join(names, ",") + ")",
+	}
+}
+
+func (r *Type) String() string {
+	return r.name
+}
+
+// Dynamic reports whether the encoding of the type has a variable length.
+func (r *Type) Dynamic() bool {
+	switch r.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return r.Elem.Dynamic()
+	case TupleKind:
+		for _, c := range r.Components {
+			if c.Type.Dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize is the number of bytes the type takes up in the head of its
+// enclosing tuple.
+func (r *Type) headSize() int {
+	if r.Dynamic() {
+		return 32
+	}
+
+	switch r.Kind {
+	case ArrayKind:
+		return r.Size * r.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, c := range r.Components {
+			size += c.Type.headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+// parseArgs parses a comma separated argument list like
+// "address indexed from, uint256 value". Nested tuples are kept together.
+func parseArgs(s string, allowIndexed bool) ([]Argument, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("abi: unbalanced parentheses in %q", s)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("abi: unbalanced parentheses in %q", s)
+	}
+	parts = append(parts, s[start:])
+
+	args := make([]Argument, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+
+		// the type is everything up to the first space outside of a tuple
+		typeEnd := len(part)
+		depth := 0
+		for j, c := range part {
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			} else if c == ' ' && depth == 0 {
+				typeEnd = j
+				break
+			}
+		}
+
+		typ, err := ParseType(part[:typeEnd])
+		if err != nil {
+			return nil, err
+		}
+		args[i].Type = typ
+
+		words := strings.Fields(part[typeEnd:])
+		if len(words) > 0 && words[0] == "indexed" {
+			if !allowIndexed {
+				return nil, fmt.Errorf("abi: unexpected indexed in %q", part)
+			}
+			args[i].Indexed = true
+			words = words[1:]
+		}
+		if len(words) > 0 && (words[0] == "memory" || words[0] == "calldata") {
+			words = words[1:]
+		}
+		switch len(words) {
+		case 0:
+		case 1:
+			args[i].Name = words[0]
+		default:
+			return nil, fmt.Errorf("abi: invalid argument %q", part)
+		}
+	}
+	return args, nil
+}
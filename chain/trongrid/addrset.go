@@ -0,0 +1,76 @@
+package trongrid
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/joshuayildiz/wallet/tronaddr"
+)
+
+// AddrSet is a set of addresses that can be changed while a watcher uses it.
+type AddrSet struct {
+	mu    sync.RWMutex
+	addrs map[string]struct{}
+}
+
+func NewAddrSet(addrs ...string) (*AddrSet, error) {
+	self := AddrSet{addrs: make(map[string]struct{}, len(addrs))}
+	err := self.Add(addrs...)
+	if err != nil {
+		return nil, err
+	}
+	return &self, nil
+}
+
+// Add adds addresses in base58 or hex form. Either all or none are added.
+func (r *AddrSet) Add(addrs ...string) error {
+	normalized := make([]string, len(addrs))
+	for i, addr := range addrs {
+		a, err := tronaddr.Parse(addr)
+		if err != nil {
+			return fmt.Errorf("adding %q: %w", addr, err)
+		}
+		normalized[i] = a.String()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, addr := range normalized {
+		r.addrs[addr] = struct{}{}
+	}
+	return nil
+}
+
+func (r *AddrSet) Remove(addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, addr := range addrs {
+		a, err := tronaddr.Parse(addr)
+		if err != nil {
+			continue
+		}
+		delete(r.addrs, a.String())
+	}
+}
+
+// Contains expects base58 addresses as decoded by the watcher.
+func (r *AddrSet) Contains(addr string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.addrs[addr]
+	return ok
+}
+
+func (r *AddrSet) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.addrs)
+}
+
+func (r *AddrSet) filter(hash, sender, receiver string) bool {
+	return r.Contains(sender) || r.Contains(receiver)
+}
@@ -0,0 +1,29 @@
+package trongrid
+
+import (
+	"testing"
+
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddrSet(t *testing.T) {
+	t.Parallel()
+
+	set, err := NewAddrSet(alice)
+	assert.NoError(t, err)
+	assert.True(t, set.Contains(alice))
+	assert.False(t, set.Contains(bob))
+
+	// hex form is normalized
+	assert.NoError(t, set.Add(tronaddr.MustParse(bob).Hex()))
+	assert.True(t, set.Contains(bob))
+	assert.Equal(t, 2, set.Len())
+
+	assert.Error(t, set.Add(carol, "invalid"))
+	assert.False(t, set.Contains(carol))
+
+	set.Remove(alice)
+	assert.False(t, set.Contains(alice))
+	assert.Equal(t, 1, set.Len())
+}
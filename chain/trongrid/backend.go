@@ -0,0 +1,54 @@
+package trongrid
+
+import (
+	"context"
+	"iter"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// Backend is what wallets and watchers need from a node. *Client implements
+// it over the http api of trongrid or any java-tron node, the decorators
+// Logged, Measured and Cached wrap any Backend.
+type Backend interface {
+	Network() chain.Network
+
+	Balance(ctx context.Context, addr string) (uint, error)
+
+	// Solidified blocks.
+	Now(ctx context.Context) (*Block, error)
+	BlockByNum(ctx context.Context, num uint) (*Block, error)
+	TxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error)
+	TxInfoByID(ctx context.Context, id string) (*TxInfo, error)
+
+	// Blocks of the fullnode that are not solidified yet.
+	Head(ctx context.Context) (*Block, error)
+	HeadBlockByNum(ctx context.Context, num uint) (*Block, error)
+	HeadTxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error)
+
+	CallConstant(ctx context.Context, from, contract string, method *abi.Method, args ...any) ([]any, error)
+	TriggerContract(ctx context.Context, t Trigger) (*Tx, error)
+	CreateTxWithPermission(ctx context.Context, from, to string, amt uint, permissionID int) (*Tx, error)
+	Broadcast(ctx context.Context, tx Tx) (string, error)
+}
+
+// HistorySource is implemented by backends that can list account history
+// without scanning blocks, like *Client over the v1 api.
+type HistorySource interface {
+	History(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error]
+	USDTHistory(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error]
+}
+
+// EventSource is implemented by backends that can query contract events, like
+// *Client over the v1 api. WithEventAPI needs it.
+type EventSource interface {
+	Events(ctx context.Context, contract string, q EventQuery) iter.Seq2[ContractEvent, error]
+}
+
+var (
+	_ Backend       = (*Client)(nil)
+	_ HistorySource = (*Client)(nil)
+	_ EventSource   = (*Client)(nil)
+)
@@ -0,0 +1,342 @@
+package trongrid
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// calls counts the calls per method.
+type calls struct {
+	mu sync.Mutex
+	n  map[string]int
+}
+
+func (r *calls) Observe(method string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.n == nil {
+		r.n = make(map[string]int)
+	}
+	r.n[method]++
+}
+
+func (r *calls) get(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.n[method]
+}
+
+func TestCached(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	node, client := newFakeNode(t)
+	node.addBlock(100, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+	node.addBlock(101, nil, nil)
+	node.setSolid(100)
+
+	counter := &calls{}
+	backend := Cached(Measured(client, counter), 8)
+
+	for range 2 {
+		b, err := backend.BlockByNum(ctx, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, blockID(100), b.BlockID)
+
+		infos, err := backend.TxInfoByBlockNum(ctx, 100)
+		assert.NoError(t, err)
+		assert.Len(t, infos, 1)
+
+		info, err := backend.TxInfoByID(ctx, "t1")
+		assert.NoError(t, err)
+		assert.Equal(t, 100, info.BlockNumber)
+	}
+	assert.Equal(t, 1, counter.get("BlockByNum"))
+	assert.Equal(t, 1, counter.get("TxInfoByBlockNum"))
+	assert.Equal(t, 1, counter.get("TxInfoByID"))
+
+	// not solidified yet, nothing is cached
+	for range 2 {
+		b, err := backend.BlockByNum(ctx, 101)
+		assert.NoError(t, err)
+		assert.Empty(t, b.BlockID)
+
+		infos, err := backend.TxInfoByBlockNum(ctx, 101)
+		assert.NoError(t, err)
+		assert.Empty(t, infos)
+	}
+	assert.Equal(t, 3, counter.get("BlockByNum"))
+	assert.Equal(t, 3, counter.get("TxInfoByBlockNum"))
+
+	// head calls are never cached
+	for range 2 {
+		_, err := backend.Now(ctx)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, counter.get("Now"))
+}
+
+func TestCachedEvicts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	node, client := newFakeNode(t)
+	for num := uint(100); num < 103; num++ {
+		node.addBlock(num, nil, nil)
+	}
+
+	counter := &calls{}
+	backend := Cached(Measured(client, counter), 2)
+
+	// 101 is the least recently used when 102 is added
+	for _, num := range []uint{100, 101, 100, 102} {
+		_, err := backend.BlockByNum(ctx, num)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, counter.get("BlockByNum"))
+
+	_, err := backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, counter.get("BlockByNum"))
+
+	_, err = backend.BlockByNum(ctx, 101)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, counter.get("BlockByNum"))
+}
+
+func TestCachedDisk(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	node, client := newFakeNode(t)
+	node.addBlock(0, nil, nil)
+	node.addBlock(100, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("aa01", 0, "SUCCESS")})
+	node.addBlock(101, nil, nil)
+	node.setSolid(100)
+
+	backend := Cached(client, 8, WithDiskCache(dir))
+	want, err := backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	_, err = backend.TxInfoByBlockNum(ctx, 100)
+	assert.NoError(t, err)
+	_, err = backend.TxInfoByID(ctx, "aa01")
+	assert.NoError(t, err)
+	_, err = backend.BlockByNum(ctx, 101)
+	assert.NoError(t, err)
+
+	// a new cache over the same dir only needs the genesis block of the node
+	counter := &calls{}
+	backend = Cached(Measured(client, counter), 8, WithDiskCache(dir))
+
+	b, err := backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, want.BlockID, b.BlockID)
+	assert.Equal(t, "t1", b.Transactions[0].TxID)
+	infos, err := backend.TxInfoByBlockNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "aa01", infos[0].ID)
+	info, err := backend.TxInfoByID(ctx, "aa01")
+	assert.NoError(t, err)
+	assert.Equal(t, 100, info.BlockNumber)
+	assert.Equal(t, 1, counter.get("BlockByNum"))
+	assert.Equal(t, 0, counter.get("TxInfoByBlockNum"))
+	assert.Equal(t, 0, counter.get("TxInfoByID"))
+
+	// neither unsolidified blocks nor mutable data end up on disk
+	_, err = backend.BlockByNum(ctx, 101)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, counter.get("BlockByNum"))
+	_, err = backend.Balance(ctx, alice)
+	assert.Error(t, err)
+	_, err = backend.Now(ctx)
+	assert.NoError(t, err)
+	_, err = backend.Now(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, counter.get("Now"))
+
+	// caches of other chains on the same network do not read them
+	counter = &calls{}
+	backend = Cached(Measured(otherChain{client}, counter), 8, WithDiskCache(dir))
+	_, err = backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, counter.get("BlockByNum"))
+
+	// cached values are copies
+	b, err = backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	b.Transactions[0].TxID = "changed"
+	b, err = backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", b.Transactions[0].TxID)
+	infos, err = backend.TxInfoByBlockNum(ctx, 100)
+	assert.NoError(t, err)
+	infos[0].Log = append(infos[0].Log, TxLog{})
+	infos, err = backend.TxInfoByBlockNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, infos[0].Log)
+}
+
+func TestCachedReorg(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	node, client := newFakeNode(t)
+	node.addBlock(0, nil, nil)
+	node.addBlock(100, nil, nil)
+	node.addBlock(101, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("aa01", 0, "SUCCESS")})
+	node.setSolid(101)
+
+	backend := Cached(client, 8, WithDiskCache(t.TempDir()))
+	old, err := backend.BlockByNum(ctx, 101)
+	assert.NoError(t, err)
+	_, err = backend.TxInfoByBlockNum(ctx, 101)
+	assert.NoError(t, err)
+
+	// 102 does not build on the cached 101, which is fetched again
+	node.reorg(101, nil, nil)
+	node.addBlock(102, nil, nil)
+	node.setSolid(102)
+	_, err = backend.BlockByNum(ctx, 102)
+	assert.NoError(t, err)
+
+	b, err := backend.BlockByNum(ctx, 101)
+	assert.NoError(t, err)
+	assert.NotEqual(t, old.BlockID, b.BlockID)
+	assert.Empty(t, b.Transactions)
+	infos, err := backend.TxInfoByBlockNum(ctx, 101)
+	assert.NoError(t, err)
+	assert.Empty(t, infos)
+}
+
+// otherChain is a backend on the same network with another genesis block.
+type otherChain struct {
+	Backend
+}
+
+func (r otherChain) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	b, err := r.Backend.BlockByNum(ctx, num)
+	if err == nil && num == 0 {
+		b.BlockID = strings.Repeat("ff", 32)
+	}
+	return b, err
+}
+
+// slowBackend blocks BlockByNum until release is closed.
+type slowBackend struct {
+	Backend
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (r *slowBackend) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	r.calls.Add(1)
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.Backend.BlockByNum(ctx, num)
+}
+
+func TestCachedCoalesces(t *testing.T) {
+	t.Parallel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	slow := &slowBackend{Backend: client, release: make(chan struct{})}
+	backend := Cached(slow, 8)
+
+	// the first caller gives up, the others take over its request
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, err := backend.BlockByNum(ctx, 100)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+	assert.Eventually(t, func() bool {
+		return slow.calls.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	for range 10 {
+		wg.Go(func() {
+			b, err := backend.BlockByNum(context.Background(), 100)
+			assert.NoError(t, err)
+			assert.Equal(t, blockID(100), b.BlockID)
+		})
+	}
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	assert.Eventually(t, func() bool {
+		return slow.calls.Load() == 2
+	}, time.Second, time.Millisecond)
+	close(slow.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), slow.calls.Load())
+}
+
+func TestLogged(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	backend := Logged(client, logger)
+
+	_, err := backend.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "level=DEBUG")
+	assert.Contains(t, buf.String(), "method=BlockByNum")
+
+	buf.Reset()
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = backend.Now(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "method=Now")
+}
+
+func TestDecoratorsForwardHistory(t *testing.T) {
+	t.Parallel()
+
+	_, client := newFakeNode(t)
+	backend := Logged(Cached(client, 8), slog.New(slog.DiscardHandler))
+
+	_, ok := backend.(HistorySource)
+	assert.True(t, ok)
+	_, ok = backend.(EventSource)
+	assert.True(t, ok)
+
+	// decorators do not claim what the wrapped backend lacks
+	measured := Measured(noHistory{client}, MetricsFunc(func(string, time.Duration, error) {}))
+	_, ok = measured.(HistorySource)
+	assert.False(t, ok)
+	_, ok = Cached(noHistory{client}, 8).(EventSource)
+	assert.False(t, ok)
+
+	// the helper the pool uses reports it when iterated
+	for _, err := range history(context.Background(), measured, alice, HistoryQuery{}, false) {
+		assert.True(t, strings.Contains(err.Error(), "does not support account history"))
+	}
+}
+
+// noHistory hides the optional interfaces of Client.
+type noHistory struct {
+	Backend
+}
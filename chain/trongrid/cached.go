@@ -0,0 +1,396 @@
+package trongrid
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type CacheOption func(*cached)
+
+// WithDiskCache also keeps cached responses as json files in dir, so they
+// survive restarts and are shared by processes using the same dir. Files are
+// kept apart by the genesis block of the chain, which unlike the network also
+// tells apart testnets and private nodes, so caches of different chains can
+// share dir. Errors writing to dir are ignored, the response is fetched again
+// next time.
+func WithDiskCache(dir string) CacheOption {
+	return func(r *cached) {
+		r.dir = dir
+	}
+}
+
+// Cached keeps up to size solidified blocks, their tx infos and tx infos by
+// id from next in memory, evicting the least recently used. Those never
+// change, everything else like balances and head blocks is passed through.
+// A cached block is evicted with its tx infos once a newly fetched block does
+// not build on it, so a block served from a fork does not hide the reorg.
+// Concurrent identical requests are sent to next only once.
+func Cached(next Backend, size int, opts ...CacheOption) Backend {
+	self := &cached{
+		Backend: next,
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		flights: make(map[string]*flight),
+	}
+	for _, opt := range opts {
+		opt(self)
+	}
+	return withSources(self, next)
+}
+
+type cached struct {
+	Backend
+	dir string
+
+	// directory of the chain under dir, see chainDir
+	chain atomic.Pointer[string]
+
+	// latest block known to be solidified
+	solid atomic.Uint64
+
+	mu      sync.Mutex
+	size    int
+	lru     *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+	flights map[string]*flight
+}
+
+type cacheEntry struct {
+	key string
+	v   any
+}
+
+// flight is a request in progress that others wait for.
+type flight struct {
+	done chan struct{}
+	v    any
+	err  error
+}
+
+func (r *cached) Now(ctx context.Context) (*Block, error) {
+	b, err := r.Backend.Now(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.observeSolid(b.BlockHeader.RawData.Number)
+	return b, nil
+}
+
+func (r *cached) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	b, err := cachedCall(ctx, r, blockKey(num), func() (*Block, bool, error) {
+		b, err := r.Backend.BlockByNum(ctx, num)
+		if err != nil {
+			return nil, false, err
+		}
+		// nodes return an empty block for blocks that are not solidified yet
+		if b.BlockHeader.RawData.Number != num || b.BlockID == "" {
+			return b, false, nil
+		}
+		r.checkParent(ctx, b)
+		return b, true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b.BlockID != "" {
+		r.observeSolid(num)
+	}
+	return b, nil
+}
+
+func (r *cached) TxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error) {
+	return cachedCall(ctx, r, infosKey(num), func() ([]TxInfo, bool, error) {
+		infos, err := r.Backend.TxInfoByBlockNum(ctx, num)
+		if err != nil {
+			return nil, false, err
+		}
+		// an empty list is also returned for blocks that are not solidified
+		// yet, only cache if the block is known to be
+		return infos, uint64(num) <= r.solid.Load(), nil
+	})
+}
+
+func (r *cached) TxInfoByID(ctx context.Context, id string) (*TxInfo, error) {
+	return cachedCall(ctx, r, "info/"+id, func() (*TxInfo, bool, error) {
+		info, err := r.Backend.TxInfoByID(ctx, id)
+		if err != nil {
+			return nil, false, err
+		}
+		return info, info.ID != "", nil
+	})
+}
+
+func blockKey(num uint) string {
+	return "block/" + strconv.FormatUint(uint64(num), 10)
+}
+
+func infosKey(num uint) string {
+	return "infos/" + strconv.FormatUint(uint64(num), 10)
+}
+
+// checkParent evicts the cached parent of b and its tx infos if b does not
+// build on it, the parent then came from a fork.
+func (r *cached) checkParent(ctx context.Context, b *Block) {
+	num := b.BlockHeader.RawData.Number
+	if num == 0 {
+		return
+	}
+
+	key := blockKey(num - 1)
+	v, ok := r.peek(key)
+	parent, _ := v.(*Block)
+	if !ok && !r.load(ctx, key, &parent) {
+		return
+	}
+	if parent.BlockID != b.BlockHeader.RawData.ParentHash {
+		r.evict(ctx, key)
+		r.evict(ctx, infosKey(num-1))
+	}
+}
+
+func (r *cached) observeSolid(num uint) {
+	for {
+		solid := r.solid.Load()
+		if uint64(num) <= solid || r.solid.CompareAndSwap(solid, uint64(num)) {
+			return
+		}
+	}
+}
+
+// cachedCall returns the value of key from memory or disk, otherwise fetch
+// is called once for all concurrent callers. Its value is cached if it
+// reports it as immutable. Callers get their own copy, the cached value and
+// the one of a shared fetch stay untouched.
+func cachedCall[T any](ctx context.Context, r *cached, key string, fetch func() (T, bool, error)) (T, error) {
+	if v, ok := r.get(key); ok {
+		return clone(v).(T), nil
+	}
+
+	var v T
+	if r.load(ctx, key, &v) {
+		r.put(key, v)
+		return clone(v).(T), nil
+	}
+
+	out, err := r.do(ctx, key, func() (any, error) {
+		v, immutable, err := fetch()
+		if err == nil && immutable {
+			r.put(key, v)
+			r.store(ctx, key, v)
+		}
+		return v, err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return clone(out).(T), nil
+}
+
+// clone deep copies the cached types.
+func clone(v any) any {
+	switch v := v.(type) {
+	case *Block:
+		if v == nil {
+			return v
+		}
+		b := *v
+		b.Transactions = slices.Clone(v.Transactions)
+		for i, tx := range b.Transactions {
+			b.Transactions[i] = tx.clone()
+		}
+		return &b
+	case []TxInfo:
+		infos := slices.Clone(v)
+		for i, info := range infos {
+			infos[i] = info.clone()
+		}
+		return infos
+	case *TxInfo:
+		if v == nil {
+			return v
+		}
+		info := v.clone()
+		return &info
+	}
+	return v
+}
+
+// do calls fn unless a call for key is in progress, in which case its result
+// is returned. If the call in progress failed because its context ended, fn
+// is called again for callers whose context is still alive.
+func (r *cached) do(ctx context.Context, key string, fn func() (any, error)) (any, error) {
+	for {
+		r.mu.Lock()
+		f, ok := r.flights[key]
+		if !ok {
+			f = &flight{done: make(chan struct{})}
+			r.flights[key] = f
+			r.mu.Unlock()
+
+			f.v, f.err = fn()
+			r.mu.Lock()
+			delete(r.flights, key)
+			r.mu.Unlock()
+			close(f.done)
+			return f.v, f.err
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-f.done:
+		}
+		if errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded) {
+			continue
+		}
+		return f.v, f.err
+	}
+}
+
+func (r *cached) get(key string) (any, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	r.lru.MoveToFront(e)
+	return e.Value.(*cacheEntry).v, true
+}
+
+// evict drops key from memory and disk.
+func (r *cached) evict(ctx context.Context, key string) {
+	r.mu.Lock()
+	if e, ok := r.entries[key]; ok {
+		r.lru.Remove(e)
+		delete(r.entries, key)
+	}
+	r.mu.Unlock()
+
+	if path, ok := r.path(ctx, key); ok {
+		os.Remove(path)
+	}
+}
+
+// peek is get without counting as a use.
+func (r *cached) peek(key string) (any, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return e.Value.(*cacheEntry).v, true
+}
+
+func (r *cached) put(key string, v any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e, ok := r.entries[key]; ok {
+		r.lru.MoveToFront(e)
+		return
+	}
+	r.entries[key] = r.lru.PushFront(&cacheEntry{key, v})
+	for r.lru.Len() > r.size {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// load reads key from disk into v, a missing or corrupt file is a miss.
+func (r *cached) load(ctx context.Context, key string, v any) bool {
+	path, ok := r.path(ctx, key)
+	if !ok {
+		return false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(b, v) == nil
+}
+
+// store writes v to disk, files are renamed into place so readers never see
+// partial ones.
+func (r *cached) store(ctx context.Context, key string, v any) {
+	path, ok := r.path(ctx, key)
+	if !ok {
+		return
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), 0o755) != nil {
+		return
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// path returns the file of key under the directory of the chain, keys that
+// do not make a safe file name are only cached in memory.
+func (r *cached) path(ctx context.Context, key string) (string, bool) {
+	if r.dir == "" || !onDisk(key) {
+		return "", false
+	}
+	dir := r.chainDir(ctx)
+	if dir == "" {
+		return "", false
+	}
+	return filepath.Join(dir, key+".json"), true
+}
+
+// chainDir returns the directory of the chain under dir, named by the id of
+// its genesis block. It is empty as long as that can not be fetched.
+func (r *cached) chainDir(ctx context.Context) string {
+	if dir := r.chain.Load(); dir != nil {
+		return *dir
+	}
+
+	genesis, err := r.Backend.BlockByNum(ctx, 0)
+	if err != nil || !onDisk("genesis/"+genesis.BlockID) {
+		return ""
+	}
+	dir := filepath.Join(r.dir, genesis.BlockID)
+	r.chain.Store(&dir)
+	return dir
+}
+
+// onDisk reports whether key makes a safe file name, tx ids that are not hex
+// are only cached in memory.
+func onDisk(key string) bool {
+	_, name, _ := strings.Cut(key, "/")
+	return name != "" && strings.Trim(name, "0123456789abcdefABCDEF") == ""
+}
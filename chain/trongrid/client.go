@@ -3,34 +3,64 @@ package trongrid
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
-	"strconv"
-	"time"
+	"strings"
 
-	"github.com/btcsuite/btcutil/base58"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/joshuayildiz/wallet/chain"
 )
 
 type Client struct {
-	Net    chain.Network
-	apikey string
-	client *http.Client
+	Net     chain.Network
+	apikey  string
+	baseURL string
+	client  *http.Client
+	limits  limits
+	limiter *limiter
 }
 
-func New(net chain.Network, apikey string) *Client {
+type ClientOption func(*Client)
+
+// WithBaseURL makes the client talk to a different node than trongrid, e.g.
+// a self-hosted one.
+func WithBaseURL(url string) ClientOption {
+	return func(r *Client) {
+		r.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+func New(net chain.Network, apikey string, opts ...ClientOption) *Client {
 	retryableClient := retryablehttp.NewClient()
 	retryableClient.RetryMax = 3
 	retryableClient.Logger = nil
-	return &Client{
+	self := &Client{
 		Net:    net,
 		apikey: apikey,
 		client: retryableClient.StandardClient(),
 	}
+	for _, opt := range opts {
+		opt(self)
+	}
+
+	if self.limits.qps > 0 || self.limits.quota > 0 || len(self.limits.keys) > 0 {
+		keys := self.limits.keys
+		if apikey != "" || len(keys) == 0 {
+			keys = append([]string{apikey}, keys...)
+		}
+		self.limiter = newLimiter(retryableClient.HTTPClient.Transport, keys, self.limits)
+		retryableClient.HTTPClient.Transport = self.limiter
+		retryableClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+			if errors.Is(err, ErrQuotaExceeded) {
+				return false, err
+			}
+			return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+		}
+	}
+	return self
 }
 
 func (r *Client) Balance(ctx context.Context, addr string) (uint, error) {
@@ -65,10 +95,21 @@ func (r *Client) Balance(ctx context.Context, addr string) (uint, error) {
 	return data.Balance, nil
 }
 
+// Now returns the latest solidified block.
 func (r *Client) Now(ctx context.Context) (*Block, error) {
+	return r.nowBlock(ctx, "/walletsolidity")
+}
+
+// Head returns the latest block of the fullnode, which is not solidified yet
+// and can still be reorganized away.
+func (r *Client) Head(ctx context.Context) (*Block, error) {
+	return r.nowBlock(ctx, "/wallet")
+}
+
+func (r *Client) nowBlock(ctx context.Context, api string) (*Block, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
-		http.MethodGet, r.url("/walletsolidity/getnowblock"),
+		http.MethodGet, r.url(api+"/getnowblock"),
 		nil,
 	)
 	if err != nil {
@@ -96,12 +137,21 @@ func (r *Client) Now(ctx context.Context) (*Block, error) {
 }
 
 func (r *Client) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	return r.blockByNum(ctx, "/walletsolidity", num)
+}
+
+// HeadBlockByNum is BlockByNum for blocks that are not solidified yet.
+func (r *Client) HeadBlockByNum(ctx context.Context, num uint) (*Block, error) {
+	return r.blockByNum(ctx, "/wallet", num)
+}
+
+func (r *Client) blockByNum(ctx context.Context, api string, num uint) (*Block, error) {
 	body := map[string]any{"num": num}
 	bodyBytes, _ := json.Marshal(body)
 
 	req, err := http.NewRequestWithContext(
 		ctx,
-		http.MethodPost, r.url("/walletsolidity/getblockbynum"),
+		http.MethodPost, r.url(api+"/getblockbynum"),
 		bytes.NewBuffer(bodyBytes),
 	)
 	if err != nil {
@@ -130,12 +180,22 @@ func (r *Client) BlockByNum(ctx context.Context, num uint) (*Block, error) {
 }
 
 func (r *Client) TxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error) {
+	return r.txInfoByBlockNum(ctx, "/walletsolidity", num)
+}
+
+// HeadTxInfoByBlockNum is TxInfoByBlockNum for blocks that are not solidified
+// yet.
+func (r *Client) HeadTxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error) {
+	return r.txInfoByBlockNum(ctx, "/wallet", num)
+}
+
+func (r *Client) txInfoByBlockNum(ctx context.Context, api string, num uint) ([]TxInfo, error) {
 	body := map[string]any{"num": num}
 	bodyBytes, _ := json.Marshal(body)
 
 	req, err := http.NewRequestWithContext(
 		ctx,
-		http.MethodPost, r.url("/walletsolidity/gettransactioninfobyblocknum"),
+		http.MethodPost, r.url(api+"/gettransactioninfobyblocknum"),
 		bytes.NewBuffer(bodyBytes),
 	)
 	if err != nil {
@@ -198,12 +258,21 @@ func (r *Client) TxInfoByID(ctx context.Context, id string) (*TxInfo, error) {
 }
 
 func (r *Client) CreateTx(ctx context.Context, from, to string, amt uint) (*Tx, error) {
+	return r.CreateTxWithPermission(ctx, from, to, amt, 0)
+}
+
+// CreateTxWithPermission creates a trx transfer that has to be signed by the
+// keys of the given permission of the sender, 0 being the owner permission.
+func (r *Client) CreateTxWithPermission(ctx context.Context, from, to string, amt uint, permissionID int) (*Tx, error) {
 	body := map[string]any{
 		"owner_address": from,
 		"to_address":    to,
 		"amount":        amt,
 		"visible":       true,
 	}
+	if permissionID != 0 {
+		body["Permission_id"] = permissionID
+	}
 	bodyBytes, _ := json.Marshal(body)
 
 	req, err := http.NewRequestWithContext(
@@ -236,7 +305,35 @@ func (r *Client) CreateTx(ctx context.Context, from, to string, amt uint) (*Tx,
 	return &data, nil
 }
 
+// BroadcastError is returned when the node rejects a transaction.
+type BroadcastError struct {
+	Code    string
+	Message string
+}
+
+func (r *BroadcastError) Error() string {
+	return fmt.Sprintf("broadcast result %s: %s", r.Code, r.Message)
+}
+
+// NotBroadcastError wraps errors that happened before a transaction was
+// broadcast, e.g. while validating or creating it. Retrying after one can not
+// send the same transfer twice.
+type NotBroadcastError struct {
+	Err error
+}
+
+func (r *NotBroadcastError) Error() string {
+	return r.Err.Error()
+}
+
+func (r *NotBroadcastError) Unwrap() error {
+	return r.Err
+}
+
+// Broadcast sends a signed tx, with PriorityHigh if the client is rate
+// limited.
 func (r *Client) Broadcast(ctx context.Context, tx Tx) (string, error) {
+	ctx = WithPriority(ctx, PriorityHigh)
 	bodyBytes, _ := json.Marshal(tx)
 
 	req, err := http.NewRequestWithContext(
@@ -271,82 +368,67 @@ func (r *Client) Broadcast(ctx context.Context, tx Tx) (string, error) {
 		return "", fmt.Errorf("decoding broadcast tx result: %w", err)
 	}
 	if !data.Result {
-		return "", fmt.Errorf("broadcast result %s: %s", data.Code, data.Message)
+		return "", &BroadcastError{Code: data.Code, Message: data.Message}
 	}
 
 	return data.Txid, nil
 }
 
-func (r *Client) USDTBalance(ctx context.Context, addr string) (uint, error) {
+// AccountPermissionUpdate creates a transaction replacing the permissions of
+// addr. Witness may be nil for accounts that are not witnesses.
+func (r *Client) AccountPermissionUpdate(ctx context.Context, addr string, owner Permission, actives []Permission, witness *Permission) (*Tx, error) {
 	body := map[string]any{
-		"owner_address":     addr,
-		"contract_address":  usdtContractAddr(r.Net),
-		"function_selector": "balanceOf(address)",
-		"parameter":         abiEncodeAddr(addr),
-		"call_value":        0,
-		"visible":           true,
+		"owner_address": addr,
+		"owner":         owner,
+		"actives":       actives,
+		"visible":       true,
+	}
+	if witness != nil {
+		body["witness"] = witness
 	}
 	bodyBytes, _ := json.Marshal(body)
 
 	req, err := http.NewRequestWithContext(
 		ctx,
-		http.MethodPost, r.url("/walletsolidity/triggerconstantcontract"),
+		http.MethodPost, r.url("/wallet/accountpermissionupdate"),
 		bytes.NewBuffer(bodyBytes),
 	)
 	if err != nil {
-		return 0, fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("TRON-PRO-API-KEY", r.apikey)
 
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("getting usdt balance of addr %s: %w", addr, err)
+		return nil, fmt.Errorf("updating permissions of %s: %w", addr, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("getting usdt balance of addr %s: %s", addr, resp.Status)
+		return nil, fmt.Errorf("updating permissions of %s: %s", addr, resp.Status)
 	}
 
-	var data TriggerConstContract
+	var data Tx
 	err = json.NewDecoder(resp.Body).Decode(&data)
 	if err != nil {
-		return 0, fmt.Errorf("decoding usdt balance of addr %s: %w", addr, err)
-	}
-	if !data.Result.Result {
-		return 0, fmt.Errorf("usdt balance result of addr %s: %v", addr, data.Result.Result)
-	}
-	if len(data.ConstantResult) == 0 {
-		return 0, fmt.Errorf("usdt balance result of addr %s: constantresult was empty", addr)
+		return nil, fmt.Errorf("decoding permission update tx: %w", err)
 	}
-
-	cRes := data.ConstantResult[0]
-	balance, err := strconv.ParseUint(cRes, 16, 64)
-	if err != nil {
-		return 0, fmt.Errorf("parsing usdt balance of addr %s: %w", addr, err)
+	if data.TxID == "" {
+		return nil, fmt.Errorf("updating permissions of %s: node returned no tx", addr)
 	}
 
-	return uint(balance), nil
+	return &data, nil
 }
 
-func (r *Client) SendUSDT(from, to string, amt uint) (*Tx, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	body := map[string]any{
-		"owner_address":     from,
-		"contract_address":  usdtContractAddr(r.Net),
-		"function_selector": "transfer(address,uint256)",
-		"parameter":         abiEncodeSend(to, amt),
-		"visible":           true,
-		"fee_limit":         10_000_000, // 10 usdt
-	}
-	bodyBytes, _ := json.Marshal(body)
+// SignWeight reports which keys signed tx so far and whether they are enough
+// to satisfy its permission.
+func (r *Client) SignWeight(ctx context.Context, tx Tx) (*SignWeight, error) {
+	bodyBytes, _ := json.Marshal(tx)
 
 	req, err := http.NewRequestWithContext(
 		ctx,
-		http.MethodPost, r.url("/wallet/triggersmartcontract"),
+		http.MethodPost, r.url("/wallet/getsignweight"),
 		bytes.NewBuffer(bodyBytes),
 	)
 	if err != nil {
@@ -357,55 +439,53 @@ func (r *Client) SendUSDT(from, to string, amt uint) (*Tx, error) {
 
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("sending usdt: %w", err)
+		return nil, fmt.Errorf("fetching sign weight of %s: %w", tx.TxID, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("sending usdt: %s", resp.Status)
+		return nil, fmt.Errorf("fetching sign weight of %s: %s", tx.TxID, resp.Status)
 	}
 
-	var data TriggerSmartContract
+	var data SignWeight
 	err = json.NewDecoder(resp.Body).Decode(&data)
 	if err != nil {
-		return nil, fmt.Errorf("decoding usdt tx: %w", err)
-	}
-	if !data.Result.Result {
-		return nil, fmt.Errorf("sending usdt result: %v", data.Result.Result)
+		return nil, fmt.Errorf("decoding sign weight of %s: %w", tx.TxID, err)
 	}
 
-	return &data.Transaction, nil
+	return &data, nil
 }
 
-func abiEncodeSend(addr string, amt uint) string {
-	encodedAddr := abiEncodeAddr(addr)
-	encodedAmt := abiEncodeUint(amt)
-	return encodedAddr + encodedAmt
+func (r *Client) USDTBalance(ctx context.Context, addr string) (uint, error) {
+	return USDTBalance(ctx, r, addr)
 }
 
-func abiEncodeAddr(addr string) string {
-	addrBytes := base58.Decode(addr)
+func (r *Client) SendUSDT(ctx context.Context, from, to string, amt uint) (*Tx, error) {
+	return SendUSDT(ctx, r, from, to, amt)
+}
 
-	// extract address, leaves 20 bytes
-	addrBytes = addrBytes[1:21]
+func (r *Client) USDTAllowance(ctx context.Context, owner, spender string) (*big.Int, error) {
+	return USDTAllowance(ctx, r, owner, spender)
+}
 
-	// pad to total of 32 bytes
-	padding := [12]byte{}
-	addrBytes = append(padding[:], addrBytes...)
+func (r *Client) ApproveUSDT(ctx context.Context, owner, spender string, amt *big.Int) (*Tx, error) {
+	return ApproveUSDT(ctx, r, owner, spender, amt)
+}
 
-	// done
-	return hex.EncodeToString(addrBytes)
+func (r *Client) TransferFromUSDT(ctx context.Context, spender, from, to string, amt uint) (*Tx, error) {
+	return TransferFromUSDT(ctx, r, spender, from, to, amt)
 }
 
-func abiEncodeUint(v uint) string {
-	value := big.NewInt(int64(v))
-	b := value.Bytes()
-	out := make([]byte, 32)
-	copy(out[32-len(b):], b)
-	return hex.EncodeToString(out)
+// Network implements Backend.
+func (r *Client) Network() chain.Network {
+	return r.Net
 }
 
 func (r *Client) url(path string) string {
+	if r.baseURL != "" {
+		return r.baseURL + path
+	}
+
 	switch r.Net {
 	case chain.Mainnet:
 		return "https://api.trongrid.io" + path
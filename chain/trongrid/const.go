@@ -1,13 +1,28 @@
 package trongrid
 
 import (
+	"github.com/joshuayildiz/wallet/abi"
 	"github.com/joshuayildiz/wallet/chain"
 )
 
+var (
+	trc20BalanceOf = abi.MustParseMethod("balanceOf(address) returns (uint256)")
+	trc20Transfer  = abi.MustParseMethod("transfer(address,uint256) returns (bool)")
+
+	trc20Approve      = abi.MustParseMethod("approve(address,uint256) returns (bool)")
+	trc20Allowance    = abi.MustParseMethod("allowance(address,address) returns (uint256)")
+	trc20TransferFrom = abi.MustParseMethod("transferFrom(address,address,uint256) returns (bool)")
+
+	trc20ApprovalEvent = abi.MustParseEvent("Approval(address indexed owner, address indexed spender, uint256 value)")
+)
+
 // This value is basically precomputed keccak256('Transfer(address,address,uint256)')
 // You can verify this by yourself if you want.
 const encodedTransferEvent = "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
 
+// Same for keccak256('Approval(address,address,uint256)').
+const encodedApprovalEvent = "8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+
 // Values below are taken from https://tether.to/en/supported-protocols/
 
 func usdtContractAddr(net chain.Network) string {
@@ -0,0 +1,193 @@
+package trongrid
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/joshuayildiz/wallet/abi"
+)
+
+// RevertError is returned when a contract call reverts.
+type RevertError struct {
+	Code   string
+	Reason string
+	Data   []byte
+}
+
+func (r *RevertError) Error() string {
+	if r.Reason == "" {
+		return fmt.Sprintf("contract reverted: %s", r.Code)
+	}
+	return fmt.Sprintf("contract reverted: %s: %s", r.Code, r.Reason)
+}
+
+// Trigger describes a state changing contract call.
+type Trigger struct {
+	From     string
+	Contract string
+	Method   *abi.Method
+	Args     []any
+
+	// Sun sent along with the call.
+	CallValue uint
+
+	// Trc10 token sent along with the call.
+	TokenID    uint
+	TokenValue uint
+
+	// Maximum sun burnt for energy.
+	FeeLimit uint
+
+	// Permission of From that signs the call, 0 being the owner permission.
+	PermissionID int
+}
+
+// CallConstant runs a read-only call against the solidified state and returns
+// the decoded outputs of method. The call is made as from, which contracts
+// looking at msg.sender see, an empty from makes it without an owner.
+func (r *Client) CallConstant(ctx context.Context, from, contract string, method *abi.Method, args ...any) ([]any, error) {
+	parameter, err := abi.Encode(method.Inputs, args...)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+
+	body := map[string]any{
+		"contract_address":  contract,
+		"function_selector": method.Sig(),
+		"parameter":         hex.EncodeToString(parameter),
+		"call_value":        0,
+		"visible":           true,
+	}
+	if from != "" {
+		body["owner_address"] = from
+	}
+	bodyBytes, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost, r.url("/walletsolidity/triggerconstantcontract"),
+		bytes.NewBuffer(bodyBytes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("TRON-PRO-API-KEY", r.apikey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("calling %s on %s: %s", method.Name, contract, resp.Status)
+	}
+
+	var data TriggerConstContract
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s result of %s: %w", method.Name, contract, err)
+	}
+	if !data.Result.Result {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, resultError(data.Result.Code, data.Result.Message))
+	}
+	if len(data.ConstantResult) == 0 {
+		return nil, fmt.Errorf("calling %s on %s: constantresult was empty", method.Name, contract)
+	}
+
+	cRes, err := hex.DecodeString(data.ConstantResult[0])
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s result of %s: %w", method.Name, contract, err)
+	}
+	if len(data.Transaction.Ret) > 0 && data.Transaction.Ret[0].Ret == "FAILED" {
+		reason, _ := abi.DecodeRevert(cRes, nil)
+		err = &RevertError{Code: "REVERT", Reason: reason, Data: cRes}
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+
+	values, err := method.Unpack(cRes)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s result of %s: %w", method.Name, contract, err)
+	}
+
+	return values, nil
+}
+
+// TriggerContract creates an unsigned transaction calling a contract.
+func (r *Client) TriggerContract(ctx context.Context, t Trigger) (*Tx, error) {
+	parameter, err := abi.Encode(t.Method.Inputs, t.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("triggering %s on %s: %w", t.Method.Name, t.Contract, err)
+	}
+
+	body := map[string]any{
+		"owner_address":     t.From,
+		"contract_address":  t.Contract,
+		"function_selector": t.Method.Sig(),
+		"parameter":         hex.EncodeToString(parameter),
+		"call_value":        t.CallValue,
+		"fee_limit":         t.FeeLimit,
+		"visible":           true,
+	}
+	if t.TokenID != 0 {
+		body["token_id"] = t.TokenID
+		body["call_token_value"] = t.TokenValue
+	}
+	if t.PermissionID != 0 {
+		body["Permission_id"] = t.PermissionID
+	}
+	bodyBytes, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost, r.url("/wallet/triggersmartcontract"),
+		bytes.NewBuffer(bodyBytes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("TRON-PRO-API-KEY", r.apikey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("triggering %s on %s: %w", t.Method.Name, t.Contract, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("triggering %s on %s: %s", t.Method.Name, t.Contract, resp.Status)
+	}
+
+	var data TriggerSmartContract
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s tx: %w", t.Method.Name, err)
+	}
+	if !data.Result.Result {
+		return nil, fmt.Errorf("triggering %s on %s: %w", t.Method.Name, t.Contract, resultError(data.Result.Code, data.Result.Message))
+	}
+
+	return &data.Transaction, nil
+}
+
+// resultError builds an error from a failed node result. The message is
+// usually hex encoded.
+func resultError(code, message string) error {
+	if decoded, err := hex.DecodeString(message); err == nil {
+		message = string(decoded)
+	}
+	if code == "CONTRACT_VALIDATE_ERROR" || code == "CONTRACT_EXE_ERROR" {
+		return &RevertError{Code: code, Reason: message}
+	}
+	if message == "" {
+		return errors.New(code)
+	}
+	return fmt.Errorf("%s: %s", code, message)
+}
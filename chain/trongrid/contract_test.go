@@ -0,0 +1,89 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallConstant(t *testing.T) {
+	t.Parallel()
+
+	var caller any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch body["function_selector"] {
+		case "balanceOf(address)":
+			assert.Equal(t, caller, body["owner_address"])
+			assert.Equal(t, "000000000000000000000000a614f803b6fd780986a42c78ec9c7f77e6ded13c", body["parameter"])
+			// larger than uint64
+			w.Write([]byte(`{"result": {"result": true}, "constant_result": ["0000000000000000000000000000000000000000000000010000000000000000"]}`))
+		case "fail()":
+			// read-only calls have no owner unless one is given
+			assert.NotContains(t, body, "owner_address")
+			w.Write([]byte(`{
+				"result": {"result": true},
+				"constant_result": ["08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000046e6f706500000000000000000000000000000000000000000000000000000000"],
+				"transaction": {"ret": [{"ret": "FAILED"}]}
+			}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New(chain.Mainnet, "", WithBaseURL(server.URL))
+	ctx := context.Background()
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	values, err := client.CallConstant(ctx, "", usdt, trc20BalanceOf, usdt)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(1), 64), values[0])
+
+	caller = usdt
+	_, err = client.USDTBalance(ctx, usdt)
+	assert.ErrorContains(t, err, "overflows")
+
+	_, err = client.CallConstant(ctx, "", usdt, abi.MustParseMethod("fail()"))
+	var revertErr *RevertError
+	assert.ErrorAs(t, err, &revertErr)
+	assert.Equal(t, "nope", revertErr.Reason)
+}
+
+func TestTriggerContract(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+
+		assert.Equal(t, "/wallet/triggersmartcontract", r.URL.Path)
+		assert.Equal(t, "transfer(address,uint256)", body["function_selector"])
+		assert.Equal(t, float64(100), body["call_value"])
+		assert.Equal(t, float64(2), body["Permission_id"])
+
+		w.Write([]byte(`{"result": {"result": true}, "transaction": {"txID": "abc", "raw_data_hex": "00"}}`))
+	}))
+	defer server.Close()
+
+	client := New(chain.Mainnet, "", WithBaseURL(server.URL))
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	tx, err := client.TriggerContract(context.Background(), Trigger{
+		From:         usdt,
+		Contract:     usdt,
+		Method:       trc20Transfer,
+		Args:         []any{usdt, 1},
+		CallValue:    100,
+		PermissionID: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", tx.TxID)
+}
@@ -0,0 +1,280 @@
+package trongrid
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"math/big"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/cursor"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// EventQuery filters contract events. Zero values do not filter.
+type EventQuery struct {
+	EventName   string
+	BlockNumber uint
+
+	MinBlockTimestamp time.Time
+	MaxBlockTimestamp time.Time
+
+	// "block_timestamp,desc" or "block_timestamp,asc", the api defaults to
+	// descending.
+	OrderBy string
+
+	OnlyConfirmed bool
+
+	// Page size, at most 200. Defaults to 200.
+	Limit int
+}
+
+func (r *EventQuery) values(fingerprint string) url.Values {
+	v := url.Values{}
+	v.Set("limit", "200")
+	if r.Limit > 0 {
+		v.Set("limit", strconv.Itoa(min(r.Limit, 200)))
+	}
+	if r.EventName != "" {
+		v.Set("event_name", r.EventName)
+	}
+	if r.BlockNumber != 0 {
+		v.Set("block_number", strconv.FormatUint(uint64(r.BlockNumber), 10))
+	}
+	if !r.MinBlockTimestamp.IsZero() {
+		v.Set("min_block_timestamp", strconv.FormatInt(r.MinBlockTimestamp.UnixMilli(), 10))
+	}
+	if !r.MaxBlockTimestamp.IsZero() {
+		v.Set("max_block_timestamp", strconv.FormatInt(r.MaxBlockTimestamp.UnixMilli(), 10))
+	}
+	if r.OrderBy != "" {
+		v.Set("order_by", r.OrderBy)
+	}
+	if r.OnlyConfirmed {
+		v.Set("only_confirmed", "true")
+	}
+	if fingerprint != "" {
+		v.Set("fingerprint", fingerprint)
+	}
+	return v
+}
+
+// ContractEvents returns a page of events emitted by contract, pass the
+// fingerprint of the previous page to get the next one.
+func (r *Client) ContractEvents(ctx context.Context, contract string, q EventQuery, fingerprint string) (*ContractEventPage, error) {
+	var data ContractEventPage
+	err := r.getV1(ctx, "/v1/contracts/"+url.PathEscape(contract)+"/events", q.values(fingerprint), &data)
+	if err != nil {
+		return nil, fmt.Errorf("fetching events of %s: %w", contract, err)
+	}
+	return &data, nil
+}
+
+// TxEvents returns the events emitted by transaction id.
+func (r *Client) TxEvents(ctx context.Context, id string) ([]ContractEvent, error) {
+	var data ContractEventPage
+	err := r.getV1(ctx, "/v1/transactions/"+url.PathEscape(id)+"/events", url.Values{}, &data)
+	if err != nil {
+		return nil, fmt.Errorf("fetching events of %s: %w", id, err)
+	}
+	return data.Data, nil
+}
+
+// Events yields all events of contract matching q, following the pages.
+func (r *Client) Events(ctx context.Context, contract string, q EventQuery) iter.Seq2[ContractEvent, error] {
+	return func(yield func(ContractEvent, error) bool) {
+		fingerprint := ""
+		for {
+			page, err := r.ContractEvents(ctx, contract, q, fingerprint)
+			if err != nil {
+				yield(ContractEvent{}, err)
+				return
+			}
+
+			for _, e := range page.Data {
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			fingerprint = page.Meta.Fingerprint
+			if fingerprint == "" || len(page.Data) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// transferEvent maps a trc20 Transfer event.
+func transferEvent(net chain.Network, ce ContractEvent) (txevent.E, error) {
+	from, err := decodeEventAddr(ce.Result["from"])
+	if err != nil {
+		return txevent.E{}, fmt.Errorf("decoding sender of %s: %w", ce.TransactionID, err)
+	}
+	to, err := decodeEventAddr(ce.Result["to"])
+	if err != nil {
+		return txevent.E{}, fmt.Errorf("decoding receiver of %s: %w", ce.TransactionID, err)
+	}
+	value, ok := new(big.Int).SetString(ce.Result["value"], 10)
+	if !ok || value.Sign() < 0 {
+		return txevent.E{}, fmt.Errorf("decoding value of %s: %q is not an amount", ce.TransactionID, ce.Result["value"])
+	}
+
+	contract, err := decodeEventAddr(ce.ContractAddress)
+	if err != nil {
+		return txevent.E{}, fmt.Errorf("decoding contract of %s: %w", ce.TransactionID, err)
+	}
+
+	e := txevent.E{
+		Block:    ce.BlockNumber,
+		Hash:     ce.TransactionID,
+		LogIndex: ce.EventIndex,
+		Currency: txevent.TRC20,
+		Contract: contract,
+		Sender:   from,
+		Receiver: to,
+		Status:   txevent.StatusSuccess,
+	}
+	e.SetAmount(value)
+	if contract == usdtContractAddr(net) {
+		e.Currency = txevent.TRON_USDT
+	}
+	if ce.BlockTimestamp != 0 {
+		e.Timestamp = time.UnixMilli(ce.BlockTimestamp).UTC()
+	}
+	return e, nil
+}
+
+// decodeEventAddr accepts the 0x prefixed evm form the api uses for
+// arguments as well as base58.
+func decodeEventAddr(value string) (string, error) {
+	if len(strings.TrimPrefix(value, "0x")) == 40 {
+		addr, err := tronaddr.ParseEVM(value)
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
+	addr, err := tronaddr.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+// eventRange is the number of blocks whose events are fetched at once, so a
+// watcher that fell behind does not hold all of them in memory.
+const eventRange = 1000
+
+// doEventRange delivers the transfers of the blocks from c.Curr() up to but
+// excluding now in chunks of eventRange blocks, like the block by block loop
+// does. The cursor advances after each chunk. Failed requests are returned as
+// fetchError, the remaining blocks are fetched again on the next poll.
+func (r *Watcher) doEventRange(ctx context.Context, c cursor.Cursor, now *Block, filter func(hash, sender, receiver string) bool) error {
+	src, ok := r.trongrid.(EventSource)
+	if !ok {
+		return fmt.Errorf("backend %T does not support the events api", r.trongrid)
+	}
+
+	latest := now.BlockHeader.RawData.Number
+	if c.Curr() >= latest {
+		return nil
+	}
+
+	start, err := r.trongrid.BlockByNum(ctx, c.Curr())
+	if err != nil {
+		return &fetchError{err: fmt.Errorf("fetching block %d: %w", c.Curr(), err)}
+	}
+
+	for c.Curr() < latest {
+		from := c.Curr()
+		to := min(from+eventRange, latest)
+
+		end := now
+		if to < latest {
+			end, err = r.trongrid.BlockByNum(ctx, to)
+			if err != nil {
+				return &fetchError{err: fmt.Errorf("fetching block %d: %w", to, err)}
+			}
+		}
+
+		events, err := r.fetchEvents(ctx, src, from, to, start, end, filter)
+		if err != nil {
+			return err
+		}
+
+		err = r.deliverRange(ctx, c, to, events)
+		if err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// fetchEvents returns the transfers of the blocks from from up to but
+// excluding to, start and end being the blocks from and to.
+func (r *Watcher) fetchEvents(ctx context.Context, src EventSource, from, to uint, start, end *Block, filter func(hash, sender, receiver string) bool) ([]txevent.E, error) {
+	q := EventQuery{
+		EventName:         "Transfer",
+		MinBlockTimestamp: time.UnixMilli(start.BlockHeader.RawData.Timestamp),
+		MaxBlockTimestamp: time.UnixMilli(end.BlockHeader.RawData.Timestamp),
+		OrderBy:           "block_timestamp,asc",
+		OnlyConfirmed:     true,
+	}
+
+	var events []txevent.E
+	for _, contract := range r.eventContracts {
+		for ce, err := range src.Events(ctx, contract, q) {
+			if err != nil {
+				return nil, &fetchError{err: err}
+			}
+			if ce.BlockNumber < from || ce.BlockNumber >= to {
+				continue
+			}
+
+			e, err := transferEvent(r.trongrid.Network(), ce)
+			if err != nil {
+				return nil, err
+			}
+			if !filter(e.Hash, e.Sender, e.Receiver) {
+				continue
+			}
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// deliverRange emits events of the blocks from c.Curr() up to latest and
+// advances c past them, for backends that fetch a range at once.
+func (r *Watcher) deliverRange(ctx context.Context, c cursor.Cursor, latest uint, events []txevent.E) error {
+	// contracts are queried one after another
+	slices.SortStableFunc(events, func(a, b txevent.E) int {
+		return cmp.Or(cmp.Compare(a.Block, b.Block), cmp.Compare(a.LogIndex, b.LogIndex))
+	})
+
+	for _, e := range events {
+		err := r.emit(ctx, e)
+		if err != nil {
+			return err
+		}
+	}
+
+	for c.Curr() < latest {
+		err := c.Adv()
+		if err != nil {
+			return fmt.Errorf("advancing cursor: %w", err)
+		}
+	}
+	r.curr.Store(uint64(c.Curr()))
+	return nil
+}
@@ -0,0 +1,146 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractEvents(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/contracts/"+bob+"/events", req.URL.Path)
+		queries = append(queries, req.URL.RawQuery)
+
+		if req.URL.Query().Get("fingerprint") == "" {
+			w.Write([]byte(`{"success": true, "data": [{"transaction_id": "t1", "event_name": "Transfer", "block_number": 10}], "meta": {"fingerprint": "next"}}`))
+		} else {
+			w.Write([]byte(`{"success": true, "data": [{"transaction_id": "t2", "event_name": "Transfer", "block_number": 11}], "meta": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := New(chain.Mainnet, "", WithBaseURL(server.URL))
+
+	var ids []string
+	for e, err := range client.Events(context.Background(), bob, EventQuery{
+		EventName:         "Transfer",
+		MinBlockTimestamp: time.UnixMilli(1000),
+		OrderBy:           "block_timestamp,asc",
+	}) {
+		assert.NoError(t, err)
+		ids = append(ids, e.TransactionID)
+	}
+
+	assert.Equal(t, []string{"t1", "t2"}, ids)
+	assert.Equal(t, []string{
+		"event_name=Transfer&limit=200&min_block_timestamp=1000&order_by=block_timestamp%2Casc",
+		"event_name=Transfer&fingerprint=next&limit=200&min_block_timestamp=1000&order_by=block_timestamp%2Casc",
+	}, queries)
+}
+
+func TestWatcherEventAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	node.addBlock(100, nil, nil)
+	node.addBlock(101, nil, nil)
+	node.addBlock(102, nil, nil)
+	node.addTransferEvent(99, usdt, "t0", 0, bob, alice, 1)
+	node.addTransferEvent(100, usdt, "t1", 0, bob, alice, 2)
+	node.addTransferEvent(101, usdt, "t2", 1, bob, alice, 3)
+	node.addTransferEvent(101, usdt, "t2", 0, bob, alice, 6)
+	node.addTransferEvent(101, usdt, "t3", 0, bob, bob, 4)
+	// more than an int holds
+	node.addTransferEvent(101, usdt, "t5", 2, bob, alice, 0)
+	node.events[usdt][len(node.events[usdt])-1].Result["value"] = "100000000000000000000"
+	// not solidified yet
+	node.addTransferEvent(102, usdt, "t4", 0, bob, alice, 5)
+
+	// the first poll fails and is retried
+	node.fail(100, 1)
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return receiver == alice
+	}, WithInterval(10*time.Millisecond), WithEventAPI(usdt))
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.TRON_USDT, e.Currency)
+	assert.Equal(t, usdt, e.Contract)
+	assert.Equal(t, bob, e.Sender)
+	assert.Equal(t, alice, e.Receiver)
+	assert.Equal(t, 2, e.Amount)
+	assert.Equal(t, time.UnixMilli(blockTimestamp(100)).UTC(), e.Timestamp)
+
+	// ordered by log index within the block
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, 0, e.LogIndex)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, 1, e.LogIndex)
+
+	// the large amount is kept
+	e = <-watcher.EventCh
+	assert.Equal(t, "t5", e.Hash)
+	assert.Equal(t, "100000000000000000000", e.AmountBig().String())
+
+	node.addBlock(103, nil, nil)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t4", e.Hash)
+}
+
+func TestWatcherEventAPIChunks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+	latest := uint(100 + eventRange + 5)
+	node.addBlock(100, nil, nil)
+	node.addBlock(100+eventRange, nil, nil)
+	node.addBlock(latest, nil, nil)
+	node.addTransferEvent(100, usdt, "t1", 0, bob, alice, 1)
+	node.addTransferEvent(101, usdt, "t2", 0, bob, alice, 2)
+	node.addTransferEvent(latest-1, usdt, "t3", 0, bob, alice, 3)
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithEventAPI(usdt))
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+
+	// the cursor advances past the first chunk while t3 of the second one
+	// is still undelivered
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == uint64(100+eventRange)
+	}, time.Second, 5*time.Millisecond)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t3", e.Hash)
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == uint64(latest)
+	}, time.Second, 5*time.Millisecond)
+}
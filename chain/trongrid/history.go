@@ -0,0 +1,319 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// HistoryQuery filters account history. Zero values do not filter.
+type HistoryQuery struct {
+	MinTimestamp time.Time
+	MaxTimestamp time.Time
+
+	OnlyConfirmed bool
+	OnlyTo        bool
+	OnlyFrom      bool
+
+	// Token contract, only used for trc20 history.
+	Contract string
+
+	// Page size, at most 200. Defaults to 200.
+	Limit int
+}
+
+func (r *HistoryQuery) values(fingerprint string) url.Values {
+	v := url.Values{}
+	v.Set("limit", "200")
+	if r.Limit > 0 {
+		v.Set("limit", strconv.Itoa(min(r.Limit, 200)))
+	}
+	if !r.MinTimestamp.IsZero() {
+		v.Set("min_timestamp", strconv.FormatInt(r.MinTimestamp.UnixMilli(), 10))
+	}
+	if !r.MaxTimestamp.IsZero() {
+		v.Set("max_timestamp", strconv.FormatInt(r.MaxTimestamp.UnixMilli(), 10))
+	}
+	if r.OnlyConfirmed {
+		v.Set("only_confirmed", "true")
+	}
+	if r.OnlyTo {
+		v.Set("only_to", "true")
+	}
+	if r.OnlyFrom {
+		v.Set("only_from", "true")
+	}
+	if r.Contract != "" {
+		v.Set("contract_address", r.Contract)
+	}
+	if fingerprint != "" {
+		v.Set("fingerprint", fingerprint)
+	}
+	return v
+}
+
+// AccountTxs returns a page of transactions of addr, pass the fingerprint of
+// the previous page to get the next one.
+func (r *Client) AccountTxs(ctx context.Context, addr string, q HistoryQuery, fingerprint string) (*AccountTxPage, error) {
+	var data AccountTxPage
+	err := r.getV1(ctx, "/v1/accounts/"+url.PathEscape(addr)+"/transactions", q.values(fingerprint), &data)
+	if err != nil {
+		return nil, fmt.Errorf("fetching transactions of %s: %w", addr, err)
+	}
+	return &data, nil
+}
+
+// AccountTRC20Transfers returns a page of trc20 transfers of addr, pass the
+// fingerprint of the previous page to get the next one.
+func (r *Client) AccountTRC20Transfers(ctx context.Context, addr string, q HistoryQuery, fingerprint string) (*TRC20TransferPage, error) {
+	var data TRC20TransferPage
+	err := r.getV1(ctx, "/v1/accounts/"+url.PathEscape(addr)+"/transactions/trc20", q.values(fingerprint), &data)
+	if err != nil {
+		return nil, fmt.Errorf("fetching trc20 transfers of %s: %w", addr, err)
+	}
+	return &data, nil
+}
+
+// History yields the trx transfers of addr, newest first. Other transactions
+// of the account are skipped.
+func (r *Client) History(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error] {
+	return func(yield func(txevent.E, error) bool) {
+		fingerprint := ""
+		for {
+			page, err := r.AccountTxs(ctx, addr, q, fingerprint)
+			if err != nil {
+				yield(txevent.E{}, err)
+				return
+			}
+
+			for _, tx := range page.Data {
+				e, ok, err := accountTxEvent(tx)
+				if err != nil {
+					yield(txevent.E{}, err)
+					return
+				}
+				if !ok {
+					continue
+				}
+				if q.OnlyConfirmed {
+					e.State = txevent.StateConfirmed
+				}
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			fingerprint = page.Meta.Fingerprint
+			if fingerprint == "" || len(page.Data) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// TRC20History yields the trc20 transfers of addr, newest first. The api
+// does not return block numbers and log indices, they are looked up in the
+// events of the transaction, which takes one more request per transaction.
+// Transfers that can not be looked up are yielded as errors, iterating can go
+// on after them.
+func (r *Client) TRC20History(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error] {
+	return func(yield func(txevent.E, error) bool) {
+		var logs transferLogs
+		fingerprint := ""
+		for {
+			page, err := r.AccountTRC20Transfers(ctx, addr, q, fingerprint)
+			if err != nil {
+				yield(txevent.E{}, err)
+				return
+			}
+
+			for _, t := range page.Data {
+				e, err := r.trc20TransferEvent(t)
+				if err == nil {
+					err = r.locateTransfer(ctx, &logs, &e)
+				}
+				if err != nil {
+					if !yield(txevent.E{}, err) {
+						return
+					}
+					continue
+				}
+				if q.OnlyConfirmed {
+					e.State = txevent.StateConfirmed
+				}
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			fingerprint = page.Meta.Fingerprint
+			if fingerprint == "" || len(page.Data) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// USDTHistory is TRC20History limited to usdt.
+func (r *Client) USDTHistory(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error] {
+	q.Contract = usdtContractAddr(r.Net)
+	return r.TRC20History(ctx, addr, q)
+}
+
+func accountTxEvent(tx AccountTx) (txevent.E, bool, error) {
+	if len(tx.RawData.Contract) == 0 || tx.RawData.Contract[0].Type != "TransferContract" {
+		return txevent.E{}, false, nil
+	}
+	value := tx.RawData.Contract[0].Parameter.Value
+
+	from, err := decodeTransferAddr(value.OwnerAddress)
+	if err != nil {
+		return txevent.E{}, false, fmt.Errorf("decoding sender of %s: %w", tx.TxID, err)
+	}
+	to, err := decodeTransferAddr(value.ToAddress)
+	if err != nil {
+		return txevent.E{}, false, fmt.Errorf("decoding receiver of %s: %w", tx.TxID, err)
+	}
+
+	e := txevent.E{
+		Block:      tx.BlockNumber,
+		Hash:       tx.TxID,
+		Currency:   txevent.TRX,
+		Sender:     from,
+		Receiver:   to,
+		Amount:     value.Amount,
+		Status:     txStatus(tx.Tx, TxInfo{}),
+		EnergyFee:  tx.EnergyFee,
+		NetFee:     tx.NetFee,
+		EnergyUsed: tx.EnergyUsageTotal,
+	}
+	if len(tx.Ret) > 0 {
+		e.Fee = tx.Ret[0].Fee
+	}
+	if tx.BlockTimestamp != 0 {
+		e.Timestamp = time.UnixMilli(tx.BlockTimestamp).UTC()
+	}
+	if memo, err := hex.DecodeString(tx.RawData.Data); err == nil {
+		e.Memo = string(memo)
+	}
+	return e, true, nil
+}
+
+func (r *Client) trc20TransferEvent(t TRC20Transfer) (txevent.E, error) {
+	value, ok := new(big.Int).SetString(t.Value, 10)
+	if !ok {
+		return txevent.E{}, fmt.Errorf("decoding value of %s: %q is not a number", t.TransactionID, t.Value)
+	}
+
+	e := txevent.E{
+		Hash:     t.TransactionID,
+		Currency: txevent.TRC20,
+		Contract: t.TokenInfo.Address,
+		Sender:   t.From,
+		Receiver: t.To,
+		Status:   txevent.StatusSuccess,
+	}
+	e.SetAmount(value)
+	if t.TokenInfo.Address == usdtContractAddr(r.Net) {
+		e.Currency = txevent.TRON_USDT
+	}
+	if t.BlockTimestamp != 0 {
+		e.Timestamp = time.UnixMilli(t.BlockTimestamp).UTC()
+	}
+	return e, nil
+}
+
+// transferLogs holds the Transfer events of the transaction the last
+// transfer of a history belonged to, minus the ones already matched.
+type transferLogs struct {
+	txID   string
+	events []txevent.E
+}
+
+// locateTransfer sets the block and log index of e from the Transfer event of
+// its transaction that matches it. Alike transfers of one transaction take
+// the events in order, so their IDs differ and match the watcher's.
+func (r *Client) locateTransfer(ctx context.Context, logs *transferLogs, e *txevent.E) error {
+	if logs.txID != e.Hash {
+		ces, err := r.TxEvents(ctx, e.Hash)
+		if err != nil {
+			return err
+		}
+
+		logs.txID, logs.events = e.Hash, nil
+		for _, ce := range ces {
+			if ce.EventName != "Transfer" {
+				continue
+			}
+			te, err := transferEvent(r.Net, ce)
+			if err != nil {
+				return err
+			}
+			logs.events = append(logs.events, te)
+		}
+	}
+
+	for i, te := range logs.events {
+		if te.Contract == e.Contract && te.Sender == e.Sender && te.Receiver == e.Receiver && te.AmountBig().Cmp(e.AmountBig()) == 0 {
+			e.Block, e.LogIndex = te.Block, te.LogIndex
+			logs.events = slices.Delete(logs.events, i, i+1)
+			return nil
+		}
+	}
+	return fmt.Errorf("no Transfer event of %s matches the transfer to %s", e.Hash, e.Receiver)
+}
+
+func (r *Client) getV1(ctx context.Context, path string, query url.Values, out any) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet, r.url(path)+"?"+query.Encode(),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Add("TRON-PRO-API-KEY", r.apikey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", resp.Status)
+	}
+
+	var data struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if !data.Success {
+		return fmt.Errorf("%s", data.Error)
+	}
+
+	err = json.Unmarshal(raw, out)
+	if err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+alice+"/transactions", req.URL.Path)
+		queries = append(queries, req.URL.RawQuery)
+
+		t1 := AccountTx{Tx: transferTx("t1", bob, alice, 5), BlockNumber: 10, BlockTimestamp: 1_700_000_000_000, NetFee: 100}
+		t1.Ret = []TxRet{{ContractRet: "SUCCESS", Fee: 100}}
+		t2 := AccountTx{Tx: contractTx("t2", alice, bob, "")}
+		t3 := AccountTx{Tx: transferTx("t3", alice, bob, 7), BlockNumber: 9}
+
+		page := map[string]any{"success": true}
+		if req.URL.Query().Get("fingerprint") == "" {
+			page["data"] = []any{accountTxJSON(t1), accountTxJSON(t2)}
+			page["meta"] = map[string]any{"fingerprint": "next"}
+		} else {
+			page["data"] = []any{accountTxJSON(t3)}
+			page["meta"] = map[string]any{}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := New(chain.Mainnet, "", WithBaseURL(server.URL))
+
+	var events []txevent.E
+	for e, err := range client.History(ctx, alice, HistoryQuery{
+		MinTimestamp:  time.UnixMilli(1_600_000_000_000),
+		OnlyConfirmed: true,
+		OnlyTo:        true,
+	}) {
+		assert.NoError(t, err)
+		events = append(events, e)
+	}
+
+	assert.Equal(t, []string{
+		"limit=200&min_timestamp=1600000000000&only_confirmed=true&only_to=true",
+		"fingerprint=next&limit=200&min_timestamp=1600000000000&only_confirmed=true&only_to=true",
+	}, queries)
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, "t1", events[0].Hash)
+	assert.Equal(t, uint(10), events[0].Block)
+	assert.Equal(t, bob, events[0].Sender)
+	assert.Equal(t, alice, events[0].Receiver)
+	assert.Equal(t, 5, events[0].Amount)
+	assert.Equal(t, 100, events[0].Fee)
+	assert.Equal(t, 100, events[0].NetFee)
+	assert.Equal(t, time.UnixMilli(1_700_000_000_000).UTC(), events[0].Timestamp)
+	assert.Equal(t, txevent.StateConfirmed, events[0].State)
+	assert.Equal(t, "t3", events[1].Hash)
+}
+
+func accountTxJSON(tx AccountTx) map[string]any {
+	b, _ := json.Marshal(tx.Tx)
+	var out map[string]any
+	json.Unmarshal(b, &out)
+	out["blockNumber"] = tx.BlockNumber
+	out["block_timestamp"] = tx.BlockTimestamp
+	out["net_fee"] = tx.NetFee
+	return out
+}
+
+func TestTRC20History(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	transfer := func(id string, index int, to, value string) string {
+		return `{
+			"block_number": 10,
+			"contract_address": "` + bob + `",
+			"event_index": ` + strconv.Itoa(index) + `,
+			"event_name": "Transfer",
+			"result": {"from": "` + alice + `", "to": "` + to + `", "value": "` + value + `"},
+			"transaction_id": "` + id + `"
+		}`
+	}
+	txEvents := map[string]string{
+		"t1": transfer("t1", 0, bob, "1000000") + `, {"event_name": "Approval", "event_index": 1}, ` + transfer("t1", 2, carol, "2000000"),
+		"t2": transfer("t2", 0, bob, "100000000000000000000"),
+		"t3": transfer("t3", 4, bob, "3") + "," + transfer("t3", 7, bob, "3"),
+	}
+	var fetched []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if id, ok := strings.CutPrefix(req.URL.Path, "/v1/transactions/"); ok {
+			id = strings.TrimSuffix(id, "/events")
+			fetched = append(fetched, id)
+			w.Write([]byte(`{"success": true, "data": [` + txEvents[id] + `]}`))
+			return
+		}
+
+		assert.Equal(t, "/v1/accounts/"+alice+"/transactions/trc20", req.URL.Path)
+		assert.Equal(t, bob, req.URL.Query().Get("contract_address"))
+
+		w.Write([]byte(`{
+			"success": true,
+			"data": [{
+				"transaction_id": "t1",
+				"token_info": {"symbol": "USDT", "address": "` + bob + `", "decimals": 6, "name": "Tether USD"},
+				"block_timestamp": 1700000000000,
+				"from": "` + alice + `",
+				"to": "` + bob + `",
+				"type": "Transfer",
+				"value": "1000000"
+			}, {
+				"transaction_id": "t1",
+				"token_info": {"address": "` + bob + `"},
+				"from": "` + alice + `",
+				"to": "` + carol + `",
+				"value": "2000000"
+			}, {
+				"transaction_id": "t2",
+				"token_info": {"address": "` + bob + `"},
+				"from": "` + alice + `",
+				"to": "` + bob + `",
+				"value": "100000000000000000000"
+			}, {
+				"transaction_id": "t3",
+				"token_info": {"address": "` + bob + `"},
+				"from": "` + alice + `",
+				"to": "` + bob + `",
+				"value": "3"
+			}, {
+				"transaction_id": "t3",
+				"token_info": {"address": "` + bob + `"},
+				"from": "` + alice + `",
+				"to": "` + bob + `",
+				"value": "3"
+			}, {
+				"transaction_id": "t4",
+				"token_info": {"address": "` + bob + `"},
+				"from": "` + alice + `",
+				"to": "` + bob + `",
+				"value": "4"
+			}],
+			"meta": {"at": 1700000000001, "page_size": 1}
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(chain.Mainnet, "", WithBaseURL(server.URL))
+
+	var (
+		events []txevent.E
+		errs   []error
+	)
+	for e, err := range client.USDTHistory(ctx, alice, HistoryQuery{}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		events = append(events, e)
+	}
+
+	assert.Len(t, events, 5)
+	assert.Equal(t, txevent.TRON_USDT, events[0].Currency)
+	assert.Equal(t, bob, events[0].Contract)
+	assert.Equal(t, 1_000_000, events[0].Amount)
+	assert.Equal(t, alice, events[0].Sender)
+	assert.Equal(t, txevent.StatusSuccess, events[0].Status)
+
+	// block and log index come from the events of the transaction, which
+	// is fetched once for all its transfers
+	assert.Equal(t, uint(10), events[0].Block)
+	assert.Equal(t, "t1:0", events[0].ID())
+	assert.Equal(t, "t1:2", events[1].ID())
+	assert.Equal(t, []string{"t1", "t2", "t3", "t4"}, fetched)
+
+	// amounts beyond an int are kept
+	assert.Equal(t, "100000000000000000000", events[2].AmountBig().String())
+
+	// alike transfers of one transaction take the events in order
+	assert.Equal(t, "t3:4", events[3].ID())
+	assert.Equal(t, "t3:7", events[4].ID())
+
+	// the transfer without an event does not end the history
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "no Transfer event of t4")
+}
+
+func TestHistoryError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"success": false, "error": "invalid address"}`))
+	}))
+	defer server.Close()
+
+	client := New(chain.Mainnet, "", WithBaseURL(server.URL))
+	for _, err := range client.History(context.Background(), "x", HistoryQuery{}) {
+		assert.ErrorContains(t, err, "invalid address")
+	}
+}
@@ -0,0 +1,213 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// Logged logs every call to next with its duration, failed calls at warn
+// level and others at debug level.
+func Logged(next Backend, logger *slog.Logger) Backend {
+	return withSources(&hooked{next: next, around: func(ctx context.Context, method string, call func() error) error {
+		start := time.Now()
+		err := call()
+
+		level := slog.LevelDebug
+		if err != nil {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(ctx, level, "trongrid call",
+			slog.String("method", method),
+			slog.Duration("duration", time.Since(start)),
+			slog.Any("error", err),
+		)
+		return err
+	}}, next)
+}
+
+// Metrics receives one observation per backend call, e.g. to feed a
+// prometheus histogram.
+type Metrics interface {
+	Observe(method string, d time.Duration, err error)
+}
+
+type MetricsFunc func(method string, d time.Duration, err error)
+
+func (r MetricsFunc) Observe(method string, d time.Duration, err error) {
+	r(method, d, err)
+}
+
+// Measured reports every call to next to m.
+func Measured(next Backend, m Metrics) Backend {
+	return withSources(&hooked{next: next, around: func(ctx context.Context, method string, call func() error) error {
+		start := time.Now()
+		err := call()
+		m.Observe(method, time.Since(start), err)
+		return err
+	}}, next)
+}
+
+// hooked runs every call of next through around.
+type hooked struct {
+	next   Backend
+	around func(ctx context.Context, method string, call func() error) error
+}
+
+func (r *hooked) Network() chain.Network {
+	return r.next.Network()
+}
+
+func (r *hooked) Balance(ctx context.Context, addr string) (balance uint, err error) {
+	err = r.around(ctx, "Balance", func() error {
+		balance, err = r.next.Balance(ctx, addr)
+		return err
+	})
+	return balance, err
+}
+
+func (r *hooked) Now(ctx context.Context) (b *Block, err error) {
+	err = r.around(ctx, "Now", func() error {
+		b, err = r.next.Now(ctx)
+		return err
+	})
+	return b, err
+}
+
+func (r *hooked) BlockByNum(ctx context.Context, num uint) (b *Block, err error) {
+	err = r.around(ctx, "BlockByNum", func() error {
+		b, err = r.next.BlockByNum(ctx, num)
+		return err
+	})
+	return b, err
+}
+
+func (r *hooked) TxInfoByBlockNum(ctx context.Context, num uint) (infos []TxInfo, err error) {
+	err = r.around(ctx, "TxInfoByBlockNum", func() error {
+		infos, err = r.next.TxInfoByBlockNum(ctx, num)
+		return err
+	})
+	return infos, err
+}
+
+func (r *hooked) TxInfoByID(ctx context.Context, id string) (info *TxInfo, err error) {
+	err = r.around(ctx, "TxInfoByID", func() error {
+		info, err = r.next.TxInfoByID(ctx, id)
+		return err
+	})
+	return info, err
+}
+
+func (r *hooked) Head(ctx context.Context) (b *Block, err error) {
+	err = r.around(ctx, "Head", func() error {
+		b, err = r.next.Head(ctx)
+		return err
+	})
+	return b, err
+}
+
+func (r *hooked) HeadBlockByNum(ctx context.Context, num uint) (b *Block, err error) {
+	err = r.around(ctx, "HeadBlockByNum", func() error {
+		b, err = r.next.HeadBlockByNum(ctx, num)
+		return err
+	})
+	return b, err
+}
+
+func (r *hooked) HeadTxInfoByBlockNum(ctx context.Context, num uint) (infos []TxInfo, err error) {
+	err = r.around(ctx, "HeadTxInfoByBlockNum", func() error {
+		infos, err = r.next.HeadTxInfoByBlockNum(ctx, num)
+		return err
+	})
+	return infos, err
+}
+
+func (r *hooked) CallConstant(ctx context.Context, from, contract string, method *abi.Method, args ...any) (values []any, err error) {
+	err = r.around(ctx, "CallConstant", func() error {
+		values, err = r.next.CallConstant(ctx, from, contract, method, args...)
+		return err
+	})
+	return values, err
+}
+
+func (r *hooked) TriggerContract(ctx context.Context, t Trigger) (tx *Tx, err error) {
+	err = r.around(ctx, "TriggerContract", func() error {
+		tx, err = r.next.TriggerContract(ctx, t)
+		return err
+	})
+	return tx, err
+}
+
+func (r *hooked) CreateTxWithPermission(ctx context.Context, from, to string, amt uint, permissionID int) (tx *Tx, err error) {
+	err = r.around(ctx, "CreateTxWithPermission", func() error {
+		tx, err = r.next.CreateTxWithPermission(ctx, from, to, amt, permissionID)
+		return err
+	})
+	return tx, err
+}
+
+func (r *hooked) Broadcast(ctx context.Context, tx Tx) (hash string, err error) {
+	err = r.around(ctx, "Broadcast", func() error {
+		hash, err = r.next.Broadcast(ctx, tx)
+		return err
+	})
+	return hash, err
+}
+
+// withSources adds the optional interfaces next implements to b, which wraps
+// next. Decorators use it so they neither hide the capabilities of next nor
+// claim ones it lacks. History and Events are passed through undecorated,
+// they span many requests.
+func withSources(b, next Backend) Backend {
+	h, isHistory := next.(HistorySource)
+	e, isEvents := next.(EventSource)
+	switch {
+	case isHistory && isEvents:
+		return &struct {
+			Backend
+			HistorySource
+			EventSource
+		}{b, h, e}
+	case isHistory:
+		return &struct {
+			Backend
+			HistorySource
+		}{b, h}
+	case isEvents:
+		return &struct {
+			Backend
+			EventSource
+		}{b, e}
+	}
+	return b
+}
+
+// history forwards to b if it is a HistorySource and fails otherwise.
+func history(ctx context.Context, b Backend, addr string, q HistoryQuery, usdt bool) iter.Seq2[txevent.E, error] {
+	src, ok := b.(HistorySource)
+	if !ok {
+		return func(yield func(txevent.E, error) bool) {
+			yield(txevent.E{}, fmt.Errorf("backend %T does not support account history", b))
+		}
+	}
+	if usdt {
+		return src.USDTHistory(ctx, addr, q)
+	}
+	return src.History(ctx, addr, q)
+}
+
+func events(ctx context.Context, b Backend, contract string, q EventQuery) iter.Seq2[ContractEvent, error] {
+	src, ok := b.(EventSource)
+	if !ok {
+		return func(yield func(ContractEvent, error) bool) {
+			yield(ContractEvent{}, fmt.Errorf("backend %T does not support the events api", b))
+		}
+	}
+	return src.Events(ctx, contract, q)
+}
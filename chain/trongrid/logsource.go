@@ -0,0 +1,114 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/joshuayildiz/wallet/cursor"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// LogSource fetches logs by block range, e.g. tronrpc.Client over eth_getLogs.
+type LogSource interface {
+	// Logs returns the logs of blocks from to to, both inclusive, emitted by
+	// one of contracts. topics[i] lists the accepted values of topic i, an
+	// empty list accepts any. Topics and addresses are hex without 0x, like
+	// in TxLog.
+	Logs(ctx context.Context, from, to uint, contracts []string, topics [][]string) ([]Log, error)
+}
+
+// WithLogSource makes the watcher fetch Transfer logs of contracts from src
+// instead of fetching every block. The client passed to Watch is still used
+// for the latest solidified block. Like WithEventAPI only token transfers are
+// seen, and LogIndex is whatever src reports.
+func WithLogSource(src LogSource, contracts ...string) WatchOption {
+	return func(r *Watcher) {
+		r.logSource = src
+		r.logContracts = contracts
+	}
+}
+
+// logRange is the number of blocks fetched from a LogSource at once, nodes
+// limit the range of eth_getLogs.
+const logRange = 1000
+
+// doLogRange delivers the transfers of the blocks from c.Curr() up to but
+// excluding latest in chunks of logRange blocks, the cursor advances after
+// each. Failed requests are returned as fetchError, the remaining blocks are
+// fetched again on the next poll. Logs that can not be decoded are returned
+// as plain errors, fetching them again would not help.
+func (r *Watcher) doLogRange(ctx context.Context, c cursor.Cursor, latest uint, filter func(hash, sender, receiver string) bool) error {
+	for c.Curr() < latest {
+		from := c.Curr()
+		to := min(from+logRange, latest)
+
+		events, err := r.fetchLogs(ctx, from, to-1, filter)
+		if err != nil {
+			return err
+		}
+
+		err = r.deliverRange(ctx, c, to, events)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchLogs returns the transfers of the blocks from to to, both inclusive.
+func (r *Watcher) fetchLogs(ctx context.Context, from, to uint, filter func(hash, sender, receiver string) bool) ([]txevent.E, error) {
+	logs, err := r.logSource.Logs(ctx, from, to, r.logContracts, [][]string{{encodedTransferEvent}})
+	if err != nil {
+		return nil, &fetchError{err: fmt.Errorf("fetching logs of blocks %d to %d: %w", from, to, err)}
+	}
+
+	var events []txevent.E
+	for _, l := range logs {
+		if len(l.Topics) != 3 || l.Topics[0] != encodedTransferEvent {
+			continue
+		}
+
+		sender, err := decodeTopicAddr(l.Topics[1])
+		if err != nil {
+			return nil, fmt.Errorf("decoding sender of %s: %w", l.TxID, err)
+		}
+		receiver, err := decodeTopicAddr(l.Topics[2])
+		if err != nil {
+			return nil, fmt.Errorf("decoding receiver of %s: %w", l.TxID, err)
+		}
+		if !filter(l.TxID, sender, receiver) {
+			continue
+		}
+
+		data, err := hex.DecodeString(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding value of %s: %w", l.TxID, err)
+		}
+		contract, err := tronaddr.ParseEVM(l.Address)
+		if err != nil {
+			return nil, fmt.Errorf("decoding contract of %s: %w", l.TxID, err)
+		}
+
+		e := txevent.E{
+			Block:     l.Block,
+			BlockHash: l.BlockHash,
+			Hash:      l.TxID,
+			LogIndex:  l.LogIndex,
+			Currency:  txevent.TRC20,
+			Contract:  contract.String(),
+			Sender:    sender,
+			Receiver:  receiver,
+			Status:    txevent.StatusSuccess,
+		}
+		e.SetAmount(new(big.Int).SetBytes(data))
+		if e.Contract == usdtContractAddr(r.trongrid.Network()) {
+			e.Currency = txevent.TRON_USDT
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
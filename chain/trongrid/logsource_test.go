@@ -0,0 +1,141 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeLogSource struct {
+	logs []Log
+
+	mu     sync.Mutex
+	fail   int
+	ranges [][2]uint
+}
+
+func (r *fakeLogSource) Logs(ctx context.Context, from, to uint, contracts []string, topics [][]string) ([]Log, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fail > 0 {
+		r.fail--
+		return nil, errors.New("unavailable")
+	}
+	r.ranges = append(r.ranges, [2]uint{from, to})
+
+	var out []Log
+	for _, l := range r.logs {
+		if l.Block >= from && l.Block <= to {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func TestWatcherLogSource(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+	node.addBlock(100, nil, nil)
+	node.addBlock(101, nil, nil)
+
+	src := &fakeLogSource{logs: []Log{
+		{TxLog: transferLog(usdt, bob, alice, 5), Block: 100, TxID: "t1", LogIndex: 7},
+		{TxLog: transferLog(usdt, bob, bob, 6), Block: 100, TxID: "t2"},
+		{TxLog: transferLog(usdt, bob, alice, 8), Block: 101, TxID: "t3"},
+	}}
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return receiver == alice
+	}, WithInterval(10*time.Millisecond), WithLogSource(src, usdt))
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, 7, e.LogIndex)
+	assert.Equal(t, txevent.TRON_USDT, e.Currency)
+	assert.Equal(t, usdt, e.Contract)
+	assert.Equal(t, bob, e.Sender)
+	assert.Equal(t, 5, e.Amount)
+
+	node.addBlock(102, nil, nil)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t3", e.Hash)
+}
+
+func TestWatcherLogSourceChunks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+	latest := uint(100 + logRange + 5)
+	node.addBlock(latest, nil, nil)
+
+	huge := transferLog(usdt, bob, alice, 0)
+	huge.Data = "01" + huge.Data[2:]
+	src := &fakeLogSource{fail: 1, logs: []Log{
+		{TxLog: transferLog(usdt, bob, alice, 5), Block: 100, TxID: "t1"},
+		{TxLog: huge, Block: 101, TxID: "t2"},
+		{TxLog: transferLog(usdt, bob, alice, 8), Block: latest - 1, TxID: "t3"},
+	}}
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithLogSource(src, usdt))
+
+	// the failed fetch is retried, the large amount kept
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(1), 248), e.AmountBig())
+	e = <-watcher.EventCh
+	assert.Equal(t, "t3", e.Hash)
+
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == uint64(latest)
+	}, time.Second, 5*time.Millisecond)
+
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	assert.Equal(t, [][2]uint{{100, 100 + logRange - 1}, {100 + logRange, latest - 1}}, src.ranges)
+}
+
+func TestFetchLogsDecodeError(t *testing.T) {
+	t.Parallel()
+
+	_, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	bad := transferLog(usdt, bob, alice, 1)
+	bad.Data = "zz"
+	w := &Watcher{
+		trongrid:     client,
+		logSource:    &fakeLogSource{logs: []Log{{TxLog: bad, Block: 100, TxID: "t1"}}},
+		logContracts: []string{usdt},
+	}
+
+	// fetching again would not help, so it is not retried
+	_, err := w.fetchLogs(context.Background(), 100, 100, func(hash, sender, receiver string) bool {
+		return true
+	})
+	var fErr *fetchError
+	assert.ErrorContains(t, err, "decoding value of t1")
+	assert.False(t, errors.As(err, &fErr))
+}
@@ -0,0 +1,259 @@
+package trongrid
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/tronaddr"
+)
+
+// fakeNode serves blocks and tx infos like the fullnode and solidity node
+// apis. Blocks are solidified as soon as they are added unless setSolid was
+// called.
+type fakeNode struct {
+	mu      sync.Mutex
+	head    uint
+	solid   uint
+	lagging bool
+	blocks  map[uint]Block
+	infos   map[uint][]TxInfo
+
+	// contract -> events served by the v1 api
+	events map[string][]ContractEvent
+
+	// block num -> number of requests for it that fail
+	failing map[uint]int
+}
+
+func newFakeNode(t *testing.T) (*fakeNode, *Client) {
+	node := &fakeNode{
+		blocks:  make(map[uint]Block),
+		infos:   make(map[uint][]TxInfo),
+		events:  make(map[string][]ContractEvent),
+		failing: make(map[uint]int),
+	}
+
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+
+	return node, New(chain.Mainnet, "", WithBaseURL(server.URL))
+}
+
+func (r *fakeNode) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var body struct {
+		Num   uint   `json:"num"`
+		Value string `json:"value"`
+	}
+	json.NewDecoder(req.Body).Decode(&body)
+
+	head := r.head
+	if r.lagging && strings.HasPrefix(req.URL.Path, "/walletsolidity/") {
+		head = r.solid
+	}
+
+	var out any
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/v1/contracts/"):
+		contract := strings.Split(req.URL.Path, "/")[3]
+		query := req.URL.Query()
+		minTs, _ := strconv.ParseInt(query.Get("min_block_timestamp"), 10, 64)
+		maxTs, _ := strconv.ParseInt(query.Get("max_block_timestamp"), 10, 64)
+
+		data := []ContractEvent{}
+		for _, e := range r.events[contract] {
+			if e.BlockTimestamp >= minTs && e.BlockTimestamp <= maxTs && e.EventName == query.Get("event_name") {
+				data = append(data, e)
+			}
+		}
+		out = map[string]any{"success": true, "data": data, "meta": map[string]any{}}
+	case strings.HasSuffix(req.URL.Path, "/getnowblock"):
+		out = r.blocks[head]
+	case strings.HasSuffix(req.URL.Path, "/getblockbynum"):
+		if r.failing[body.Num] > 0 {
+			r.failing[body.Num]--
+			http.Error(w, "unavailable", http.StatusBadRequest)
+			return
+		}
+		out = Block{}
+		if body.Num <= head {
+			out = r.blocks[body.Num]
+		}
+	case strings.HasSuffix(req.URL.Path, "/gettransactioninfobyblocknum"):
+		infos := r.infos[body.Num]
+		if infos == nil || body.Num > head {
+			infos = []TxInfo{}
+		}
+		out = infos
+	case strings.HasSuffix(req.URL.Path, "/gettransactioninfobyid"):
+		out = TxInfo{}
+		for num, infos := range r.infos {
+			for _, info := range infos {
+				if info.ID == body.Value && num <= head {
+					info.BlockNumber = int(num)
+					out = info
+				}
+			}
+		}
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	json.NewEncoder(w).Encode(out)
+}
+
+// addBlock appends a block containing txs to the chain and makes it the head.
+func (r *fakeNode) addBlock(num uint, txs []Tx, infos []TxInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b Block
+	b.BlockID = blockID(num)
+	b.BlockHeader.RawData.Number = num
+	b.BlockHeader.RawData.Timestamp = blockTimestamp(num)
+	b.BlockHeader.RawData.ParentHash = blockID(num - 1)
+	if parent, ok := r.blocks[num-1]; ok {
+		b.BlockHeader.RawData.ParentHash = parent.BlockID
+	}
+	b.Transactions = txs
+
+	r.blocks[num] = b
+	r.infos[num] = infos
+	if num > r.head {
+		r.head = num
+	}
+}
+
+// fail makes the next n requests for block num fail.
+func (r *fakeNode) fail(num uint, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failing[num] = n
+}
+
+// setSolid makes num the latest solidified block, later blocks are only
+// served by the fullnode api.
+func (r *fakeNode) setSolid(num uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.solid = num
+	r.lagging = true
+}
+
+// reorg replaces block num with a block of a different id, the next block
+// is updated to build on it.
+func (r *fakeNode) reorg(num uint, txs []Tx, infos []TxInfo) {
+	r.addBlock(num, txs, infos)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.blocks[num]
+	b.BlockID = "ff" + b.BlockID[2:]
+	r.blocks[num] = b
+
+	if next, ok := r.blocks[num+1]; ok {
+		next.BlockHeader.RawData.ParentHash = b.BlockID
+		r.blocks[num+1] = next
+	}
+}
+
+// addTransferEvent adds a trc20 Transfer event to the v1 api.
+func (r *fakeNode) addTransferEvent(num uint, contract, id string, index int, from, to string, amt int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[contract] = append(r.events[contract], ContractEvent{
+		BlockNumber:     num,
+		BlockTimestamp:  blockTimestamp(num),
+		ContractAddress: contract,
+		EventIndex:      index,
+		EventName:       "Transfer",
+		TransactionID:   id,
+		Result: map[string]string{
+			"from":  "0x" + hex.EncodeToString(tronaddr.MustParse(from).EVMBytes()),
+			"to":    "0x" + hex.EncodeToString(tronaddr.MustParse(to).EVMBytes()),
+			"value": strconv.Itoa(amt),
+		},
+	})
+}
+
+func blockTimestamp(num uint) int64 {
+	return 1_600_000_000_000 + int64(num)*3000
+}
+
+func blockID(num uint) string {
+	id := make([]byte, 32)
+	id[31] = byte(num)
+	id[30] = byte(num >> 8)
+	return hex.EncodeToString(id)
+}
+
+func transferTx(id string, from, to string, amt int) Tx {
+	var c Contract
+	c.Type = "TransferContract"
+	c.Parameter.Value.OwnerAddress = tronaddr.MustParse(from).Hex()
+	c.Parameter.Value.ToAddress = tronaddr.MustParse(to).Hex()
+	c.Parameter.Value.Amount = amt
+
+	var tx Tx
+	tx.TxID = id
+	tx.RawData.Contract = []Contract{c}
+	return tx
+}
+
+func contractTx(id string, from, contract, data string) Tx {
+	var c Contract
+	c.Type = "TriggerSmartContract"
+	c.Parameter.Value.OwnerAddress = tronaddr.MustParse(from).Hex()
+	c.Parameter.Value.ContractAddress = tronaddr.MustParse(contract).Hex()
+	c.Parameter.Value.Data = data
+
+	var tx Tx
+	tx.TxID = id
+	tx.RawData.Contract = []Contract{c}
+	return tx
+}
+
+func txInfo(id string, fee int, result string, logs ...TxLog) TxInfo {
+	info := TxInfo{
+		ID:  id,
+		Fee: fee,
+		Log: logs,
+	}
+	info.Receipt.Result = result
+	return info
+}
+
+// transferLog builds a trc20 Transfer log of contract.
+func transferLog(contract, from, to string, amt uint64) TxLog {
+	amtBytes := make([]byte, 32)
+	for i := 0; i < 8; i++ {
+		amtBytes[31-i] = byte(amt >> (8 * i))
+	}
+
+	return TxLog{
+		Address: hex.EncodeToString(tronaddr.MustParse(contract).EVMBytes()),
+		Data:    hex.EncodeToString(amtBytes),
+		Topics: []string{
+			encodedTransferEvent,
+			addrTopic(from),
+			addrTopic(to),
+		},
+	}
+}
+
+func addrTopic(addr string) string {
+	return strings.Repeat("0", 24) + hex.EncodeToString(tronaddr.MustParse(addr).EVMBytes())
+}
@@ -0,0 +1,312 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// pendingTracker follows the fullnode head for WithPending. It emits pending
+// events for transfers in blocks that are not solidified yet and drops them
+// again if they do not make it into the solidified chain.
+type pendingTracker struct {
+	w             *Watcher
+	filter        func(hash, sender, receiver string) bool
+	confirmations uint
+
+	// entries and blocks are only written by run, which can read them
+	// without holding mu, except for confirmed which the watcher sets too
+	mu sync.Mutex
+
+	// event id -> pending event
+	entries map[string]*pendingEntry
+
+	// block num -> block id of blocks with entries
+	blocks map[uint]string
+
+	// next head block to scan
+	next uint
+}
+
+type pendingEntry struct {
+	e         txevent.E
+	confirmed bool
+}
+
+// WithPending also follows the fullnode head and emits events as soon as
+// their block is produced, with StatePending. A transaction whose block gets
+// reorganized away may still be included in a later block, so it is only
+// emitted again with StateDropped once the solidified chain passed its block
+// without it.
+//
+// With confirmations set to 0 events are confirmed by the watcher once their
+// block is solidified. Otherwise they are confirmed once that many blocks
+// were produced on top, which is faster than solidification but can be
+// followed by StateReverted on deeper reorgs.
+//
+// A handler set with WithHandler is called concurrently for pending and
+// confirmed events.
+func WithPending(confirmations uint) WatchOption {
+	return func(r *Watcher) {
+		r.pending = &pendingTracker{
+			w:             r,
+			confirmations: confirmations,
+			entries:       make(map[string]*pendingEntry),
+			blocks:        make(map[uint]string),
+		}
+	}
+}
+
+func (r *pendingTracker) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-time.After(r.w.interval):
+			// the next poll picks up where this one failed
+			err := r.poll(ctx)
+			var (
+				hErr *handlerError
+				fErr *fetchError
+			)
+			if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+				return
+			} else if errors.As(err, &hErr) || errors.As(err, &fErr) {
+				continue
+			} else if err != nil {
+				panic(fmt.Errorf("watcher: following head: %w", err))
+			}
+		}
+	}
+}
+
+func (r *pendingTracker) poll(ctx context.Context) error {
+	head, err := r.w.trongrid.Head(ctx)
+	if err != nil {
+		return &fetchError{err: fmt.Errorf("fetching head block: %w", err)}
+	}
+	latest := head.BlockHeader.RawData.Number
+	solid := uint(r.w.curr.Load())
+
+	err = r.settle(ctx, solid)
+	if err != nil {
+		return err
+	}
+
+	err = r.checkReorgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.next = max(r.next, solid)
+	for ; latest != 0 && r.next <= latest; r.next++ {
+		b, events, err := r.scan(ctx, r.next)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			err := r.add(ctx, e)
+			if err != nil {
+				return err
+			}
+		}
+		if len(events) > 0 {
+			r.setBlock(r.next, b.BlockID)
+		}
+	}
+
+	if r.confirmations == 0 {
+		return nil
+	}
+	for id, entry := range r.entries {
+		if latest+1 < entry.e.Block+r.confirmations {
+			continue
+		}
+
+		e := entry.e
+		e.State = txevent.StateConfirmed
+		err := r.confirm(id, func() error {
+			return r.w.emit(ctx, e)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settle forgets entries of solidified blocks, the watcher confirmed them.
+// Entries whose transaction did not make it into the solidified chain are
+// dropped.
+func (r *pendingTracker) settle(ctx context.Context, solid uint) error {
+	for id, entry := range r.entries {
+		if entry.e.Block >= solid {
+			continue
+		}
+
+		info, err := r.w.trongrid.TxInfoByID(ctx, entry.e.Hash)
+		if err != nil {
+			return &fetchError{err: fmt.Errorf("fetching tx info of %s: %w", entry.e.Hash, err)}
+		}
+		if info.ID == "" {
+			err := r.drop(ctx, id)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if uint(info.BlockNumber) >= solid {
+			// moved to a later block the watcher did not process yet
+			r.mu.Lock()
+			entry.e.Block = uint(info.BlockNumber)
+			r.mu.Unlock()
+			continue
+		}
+
+		r.mu.Lock()
+		delete(r.entries, id)
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	for num := range r.blocks {
+		if num < solid {
+			delete(r.blocks, num)
+		}
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+// checkReorgs rescans blocks with entries whose id changed. Entries missing
+// from the new block are left to settle, their transaction can still show up
+// in a later block.
+func (r *pendingTracker) checkReorgs(ctx context.Context) error {
+	for num, id := range r.blocks {
+		b, events, err := r.scan(ctx, num)
+		if err != nil {
+			return err
+		}
+		if b.BlockID == id {
+			continue
+		}
+
+		for _, e := range events {
+			err := r.add(ctx, e)
+			if err != nil {
+				return err
+			}
+		}
+		r.setBlock(num, b.BlockID)
+	}
+
+	return nil
+}
+
+// scan returns the block num of the fullnode and the events in it.
+func (r *pendingTracker) scan(ctx context.Context, num uint) (*Block, []txevent.E, error) {
+	b, err := r.w.trongrid.HeadBlockByNum(ctx, num)
+	if err != nil {
+		return nil, nil, &fetchError{err: fmt.Errorf("fetching head block %d: %w", num, err)}
+	}
+	infos, err := r.w.trongrid.HeadTxInfoByBlockNum(ctx, num)
+	if err != nil {
+		return nil, nil, &fetchError{err: fmt.Errorf("fetching tx infos of head block %d: %w", num, err)}
+	}
+
+	var events []txevent.E
+	collector := &Watcher{
+		trongrid: r.w.trongrid,
+		failed:   r.w.failed,
+		handler: func(ctx context.Context, e txevent.E) error {
+			events = append(events, e)
+			return nil
+		},
+	}
+	err = collector.doBlock(ctx, b, infos, r.filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("scanning head block %d: %w", num, err)
+	}
+
+	return b, events, nil
+}
+
+func (r *pendingTracker) add(ctx context.Context, e txevent.E) error {
+	if entry, ok := r.entries[e.ID()]; ok {
+		// same transfer in a reorganized block
+		r.mu.Lock()
+		entry.e.Block = e.Block
+		entry.e.BlockHash = e.BlockHash
+		r.mu.Unlock()
+		return nil
+	}
+
+	e.State = txevent.StatePending
+	err := r.w.emit(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.entries[e.ID()] = &pendingEntry{e: e}
+	r.mu.Unlock()
+	return nil
+}
+
+// drop emits StateDropped, or StateReverted if the event was already
+// confirmed by depth.
+func (r *pendingTracker) drop(ctx context.Context, id string) error {
+	e := r.entries[id].e
+	e.State = txevent.StateDropped
+	r.mu.Lock()
+	if r.entries[id].confirmed {
+		e.State = txevent.StateReverted
+	}
+	r.mu.Unlock()
+	err := r.w.emit(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	delete(r.entries, id)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *pendingTracker) setBlock(num uint, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.blocks[num] = id
+}
+
+// confirm calls emit unless the event was already confirmed, by depth or by
+// the watcher once its block was solidified, and marks it confirmed. Both
+// run under mu so the two can not confirm the same event twice. Events the
+// tracker does not know are always emitted.
+func (r *pendingTracker) confirm(id string, emit func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[id]
+	if ok && entry.confirmed {
+		return nil
+	}
+	err := emit()
+	if err != nil {
+		return err
+	}
+	if ok {
+		entry.confirmed = true
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package trongrid
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcherPending(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	node.setSolid(100)
+
+	cursor := &memCursor{curr: 101}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return receiver == alice
+	}, WithInterval(10*time.Millisecond), WithPending(0))
+
+	node.addBlock(101, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+	node.addBlock(102, []Tx{transferTx("t2", bob, alice, 2)}, []TxInfo{txInfo("t2", 0, "SUCCESS")})
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.StatePending, e.State)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, txevent.StatePending, e.State)
+
+	// t2 gets replaced by t3
+	node.reorg(102, []Tx{transferTx("t3", bob, alice, 3)}, []TxInfo{txInfo("t3", 0, "SUCCESS")})
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t3", e.Hash)
+	assert.Equal(t, txevent.StatePending, e.State)
+	assert.Equal(t, uint(102), e.Block)
+
+	// the watcher processes blocks below the solidified one, t2 is dropped
+	// once it is clear that it did not make it
+	node.addBlock(103, nil, nil)
+	node.setSolid(103)
+
+	var states []string
+	for range 3 {
+		e = <-watcher.EventCh
+		states = append(states, e.Hash+" "+string(e.State))
+	}
+	assert.ElementsMatch(t, []string{"t1 CONFIRMED", "t2 DROPPED", "t3 CONFIRMED"}, states)
+}
+
+func TestWatcherPendingReorg(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	node.setSolid(100)
+
+	cursor := &memCursor{curr: 101}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return receiver == alice
+	}, WithInterval(10*time.Millisecond), WithPending(1))
+
+	node.addBlock(101, []Tx{
+		transferTx("t1", bob, alice, 1),
+		transferTx("t2", bob, alice, 2),
+	}, []TxInfo{
+		txInfo("t1", 0, "SUCCESS"),
+		txInfo("t2", 0, "SUCCESS"),
+	})
+
+	for _, want := range []string{"t1 PENDING", "t2 PENDING"} {
+		e := <-watcher.EventCh
+		assert.Equal(t, want, e.Hash+" "+string(e.State))
+	}
+	var states []string
+	for range 2 {
+		e := <-watcher.EventCh
+		states = append(states, e.Hash+" "+string(e.State))
+	}
+	assert.ElementsMatch(t, []string{"t1 CONFIRMED", "t2 CONFIRMED"}, states)
+
+	// t1 is gone for good, t2 moves to the next block
+	node.reorg(101, nil, nil)
+	node.addBlock(102, []Tx{transferTx("t2", bob, alice, 2)}, []TxInfo{txInfo("t2", 0, "SUCCESS")})
+	node.addBlock(103, nil, nil)
+	node.setSolid(103)
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.StateReverted, e.State)
+
+	select {
+	case e = <-watcher.EventCh:
+		t.Fatalf("unexpected event %s %s", e.Hash, e.State)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatcherPendingConfirmations(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	node.setSolid(100)
+
+	cursor := &memCursor{curr: 101}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return receiver == alice
+	}, WithInterval(10*time.Millisecond), WithPending(2))
+
+	node.addBlock(101, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.StatePending, e.State)
+
+	node.addBlock(102, nil, nil)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.StateConfirmed, e.State)
+
+	// solidification does not confirm t1 a second time
+	node.setSolid(102)
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == 102
+	}, time.Second, 5*time.Millisecond)
+	node.addBlock(103, []Tx{transferTx("t2", bob, alice, 2)}, []TxInfo{txInfo("t2", 0, "SUCCESS")})
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, txevent.StatePending, e.State)
+}
+
+func TestPendingConfirmOnce(t *testing.T) {
+	t.Parallel()
+
+	tracker := &pendingTracker{entries: map[string]*pendingEntry{
+		"t1:0": {e: txevent.E{Hash: "t1"}},
+	}}
+
+	// the watcher and the depth check race to confirm the same event
+	var emitted atomic.Int32
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			err := tracker.confirm("t1:0", func() error {
+				emitted.Add(1)
+				return nil
+			})
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), emitted.Load())
+	assert.True(t, tracker.entries["t1:0"].confirmed)
+}
@@ -0,0 +1,421 @@
+package trongrid
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// Pool spreads calls over several backends, e.g. self-hosted nodes with
+// trongrid as a fallback. Calls go to the fastest available backend and fail
+// over to the next one on errors. A backend failing repeatedly is skipped
+// until its breaker cools down.
+type Pool struct {
+	endpoints    []*endpoint
+	failures     int
+	cooldown     time.Duration
+	interval     time.Duration
+	broadcastAll bool
+
+	// index of the endpoint sticky pools keep using, nil if not sticky
+	pinned *atomic.Int64
+}
+
+type PoolOption func(*Pool)
+
+// WithBreaker opens the breaker of a backend after failures consecutive
+// failed calls, it is skipped for cooldown afterwards. Defaults to 3 failures
+// and 30 seconds.
+func WithBreaker(failures int, cooldown time.Duration) PoolOption {
+	return func(r *Pool) {
+		r.failures = failures
+		r.cooldown = cooldown
+	}
+}
+
+// WithHealthCheck sets how often Run checks every backend, 10 seconds by
+// default.
+func WithHealthCheck(interval time.Duration) PoolOption {
+	return func(r *Pool) {
+		r.interval = interval
+	}
+}
+
+// WithBroadcastAll sends transactions to every available backend at once
+// instead of the fastest one.
+func WithBroadcastAll() PoolOption {
+	return func(r *Pool) {
+		r.broadcastAll = true
+	}
+}
+
+// NewPool creates a pool over backends, which should all be on the same
+// network. Backends are preferred in the given order until their latency is
+// known.
+func NewPool(backends []Backend, opts ...PoolOption) (*Pool, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("trongrid.NewPool: no backends")
+	}
+
+	self := &Pool{
+		failures: 3,
+		cooldown: 30 * time.Second,
+		interval: 10 * time.Second,
+	}
+	for i, b := range backends {
+		self.endpoints = append(self.endpoints, &endpoint{Backend: b, index: i})
+	}
+	for _, opt := range opts {
+		opt(self)
+	}
+	return self, nil
+}
+
+// Sticky returns a view of the pool that keeps using the backend it used
+// last until that fails, so a watcher reads consecutive blocks from the same
+// node. Breakers and latencies are shared with the pool.
+func (r *Pool) Sticky() *Pool {
+	self := *r
+	self.pinned = new(atomic.Int64)
+	self.pinned.Store(-1)
+	return &self
+}
+
+// Run checks the health of every backend periodically until ctx is done.
+// Breakers of backends that recovered are closed even if no calls are made.
+func (r *Pool) Run(ctx context.Context) error {
+	for {
+		r.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// Check fetches the latest block from every backend once.
+func (r *Pool) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, ep := range r.endpoints {
+		wg.Go(func() {
+			checkCtx, cancel := context.WithTimeout(ctx, r.interval)
+			defer cancel()
+
+			start := time.Now()
+			_, err := ep.Now(checkCtx)
+			if ctx.Err() != nil {
+				return
+			}
+			ep.observe(time.Since(start), err, r.failures, r.cooldown)
+		})
+	}
+	wg.Wait()
+}
+
+// EndpointStats describes the state of a backend of a pool.
+type EndpointStats struct {
+	// Index of the backend as passed to NewPool.
+	Index int
+
+	// Open is true while calls skip the backend.
+	Open     bool
+	Failures int
+	Latency  time.Duration
+}
+
+func (r *Pool) Stats() []EndpointStats {
+	stats := make([]EndpointStats, len(r.endpoints))
+	now := time.Now()
+	for i, ep := range r.endpoints {
+		ep.mu.Lock()
+		stats[i] = EndpointStats{
+			Index:    ep.index,
+			Open:     now.Before(ep.openUntil),
+			Failures: ep.failures,
+			Latency:  ep.latency,
+		}
+		ep.mu.Unlock()
+	}
+	return stats
+}
+
+type endpoint struct {
+	Backend
+	index int
+
+	mu        sync.Mutex
+	latency   time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+// observe records the outcome of a call. Once the cooldown passed a single
+// failure reopens the breaker, a success closes it.
+func (r *endpoint) observe(d time.Duration, err error, failures int, cooldown time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.failures++
+		if r.failures >= failures {
+			r.openUntil = time.Now().Add(cooldown)
+		}
+		return
+	}
+
+	r.failures = 0
+	r.openUntil = time.Time{}
+	if r.latency == 0 {
+		r.latency = d
+	} else {
+		// exponentially weighted moving average
+		r.latency = (4*r.latency + d) / 5
+	}
+}
+
+// order returns the endpoints to try, available ones by latency first and
+// the pinned one before them. Open ones come last as a last resort.
+func (r *Pool) order() []*endpoint {
+	type state struct {
+		ep        *endpoint
+		latency   time.Duration
+		openUntil time.Time
+	}
+
+	now := time.Now()
+	states := make([]state, len(r.endpoints))
+	for i, ep := range r.endpoints {
+		ep.mu.Lock()
+		states[i] = state{ep, ep.latency, ep.openUntil}
+		ep.mu.Unlock()
+	}
+
+	pinned := -1
+	if r.pinned != nil {
+		pinned = int(r.pinned.Load())
+	}
+
+	slices.SortStableFunc(states, func(a, b state) int {
+		aOpen, bOpen := now.Before(a.openUntil), now.Before(b.openUntil)
+		switch {
+		case aOpen != bOpen && aOpen:
+			return 1
+		case aOpen != bOpen:
+			return -1
+		case aOpen:
+			return a.openUntil.Compare(b.openUntil)
+		case a.ep.index == pinned:
+			return -1
+		case b.ep.index == pinned:
+			return 1
+		}
+		return cmp.Compare(a.latency, b.latency)
+	})
+
+	eps := make([]*endpoint, len(states))
+	for i, s := range states {
+		eps[i] = s.ep
+	}
+	return eps
+}
+
+// failover reports whether err is a failure of the backend rather than of
+// the call itself, which other backends would fail the same way.
+func failover(ctx context.Context, err error) bool {
+	var revertErr *RevertError
+	var broadcastErr *BroadcastError
+	return ctx.Err() == nil && !errors.As(err, &revertErr) && !errors.As(err, &broadcastErr)
+}
+
+// poolCall runs fn on the endpoints of r in order until it succeeds.
+func poolCall[T any](ctx context.Context, r *Pool, fn func(b Backend) (T, error)) (T, error) {
+	var errs []error
+	for _, ep := range r.order() {
+		start := time.Now()
+		v, err := fn(ep.Backend)
+		if err != nil && !failover(ctx, err) {
+			return v, err
+		}
+
+		ep.observe(time.Since(start), err, r.failures, r.cooldown)
+		if err == nil {
+			if r.pinned != nil {
+				r.pinned.Store(int64(ep.index))
+			}
+			return v, nil
+		}
+		errs = append(errs, err)
+	}
+
+	var zero T
+	return zero, fmt.Errorf("all %d backends failed: %w", len(r.endpoints), errors.Join(errs...))
+}
+
+func (r *Pool) Network() chain.Network {
+	return r.endpoints[0].Network()
+}
+
+func (r *Pool) Balance(ctx context.Context, addr string) (uint, error) {
+	return poolCall(ctx, r, func(b Backend) (uint, error) {
+		return b.Balance(ctx, addr)
+	})
+}
+
+func (r *Pool) Now(ctx context.Context) (*Block, error) {
+	return poolCall(ctx, r, func(b Backend) (*Block, error) {
+		return b.Now(ctx)
+	})
+}
+
+func (r *Pool) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	return poolCall(ctx, r, func(b Backend) (*Block, error) {
+		return b.BlockByNum(ctx, num)
+	})
+}
+
+func (r *Pool) TxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error) {
+	return poolCall(ctx, r, func(b Backend) ([]TxInfo, error) {
+		return b.TxInfoByBlockNum(ctx, num)
+	})
+}
+
+func (r *Pool) TxInfoByID(ctx context.Context, id string) (*TxInfo, error) {
+	return poolCall(ctx, r, func(b Backend) (*TxInfo, error) {
+		return b.TxInfoByID(ctx, id)
+	})
+}
+
+func (r *Pool) Head(ctx context.Context) (*Block, error) {
+	return poolCall(ctx, r, func(b Backend) (*Block, error) {
+		return b.Head(ctx)
+	})
+}
+
+func (r *Pool) HeadBlockByNum(ctx context.Context, num uint) (*Block, error) {
+	return poolCall(ctx, r, func(b Backend) (*Block, error) {
+		return b.HeadBlockByNum(ctx, num)
+	})
+}
+
+func (r *Pool) HeadTxInfoByBlockNum(ctx context.Context, num uint) ([]TxInfo, error) {
+	return poolCall(ctx, r, func(b Backend) ([]TxInfo, error) {
+		return b.HeadTxInfoByBlockNum(ctx, num)
+	})
+}
+
+func (r *Pool) CallConstant(ctx context.Context, from, contract string, method *abi.Method, args ...any) ([]any, error) {
+	return poolCall(ctx, r, func(b Backend) ([]any, error) {
+		return b.CallConstant(ctx, from, contract, method, args...)
+	})
+}
+
+func (r *Pool) TriggerContract(ctx context.Context, t Trigger) (*Tx, error) {
+	return poolCall(ctx, r, func(b Backend) (*Tx, error) {
+		return b.TriggerContract(ctx, t)
+	})
+}
+
+func (r *Pool) CreateTxWithPermission(ctx context.Context, from, to string, amt uint, permissionID int) (*Tx, error) {
+	return poolCall(ctx, r, func(b Backend) (*Tx, error) {
+		return b.CreateTxWithPermission(ctx, from, to, amt, permissionID)
+	})
+}
+
+// Broadcast sends tx to the fastest backend, or with WithBroadcastAll to all
+// available ones at once. In that case it succeeds if any backend accepted
+// tx. A backend that already knows tx counts as accepting it.
+func (r *Pool) Broadcast(ctx context.Context, tx Tx) (string, error) {
+	if !r.broadcastAll {
+		return poolCall(ctx, r, func(b Backend) (string, error) {
+			return broadcast(ctx, b, tx)
+		})
+	}
+
+	now := time.Now()
+	eps := slices.DeleteFunc(r.order(), func(ep *endpoint) bool {
+		ep.mu.Lock()
+		defer ep.mu.Unlock()
+		return now.Before(ep.openUntil)
+	})
+	if len(eps) == 0 {
+		eps = r.endpoints
+	}
+
+	hashes := make([]string, len(eps))
+	errs := make([]error, len(eps))
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Go(func() {
+			start := time.Now()
+			hashes[i], errs[i] = broadcast(ctx, ep, tx)
+			if errs[i] == nil || failover(ctx, errs[i]) {
+				ep.observe(time.Since(start), errs[i], r.failures, r.cooldown)
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			return hashes[i], nil
+		}
+	}
+	return "", fmt.Errorf("broadcasting to %d backends: %w", len(eps), errors.Join(errs...))
+}
+
+// broadcast sends tx to b, a tx that b already knows, e.g. because an
+// earlier attempt timed out after reaching it, was broadcast before.
+func broadcast(ctx context.Context, b Backend, tx Tx) (string, error) {
+	hash, err := b.Broadcast(ctx, tx)
+	var broadcastErr *BroadcastError
+	if errors.As(err, &broadcastErr) && broadcastErr.Code == "DUP_TRANSACTION_ERROR" {
+		return tx.TxID, nil
+	}
+	return hash, err
+}
+
+// History, USDTHistory and Events use the first available backend that
+// supports them, iterators can not fail over once started.
+
+func (r *Pool) History(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error] {
+	return history(ctx, r.supporting(func(b Backend) bool {
+		_, ok := b.(HistorySource)
+		return ok
+	}), addr, q, false)
+}
+
+func (r *Pool) USDTHistory(ctx context.Context, addr string, q HistoryQuery) iter.Seq2[txevent.E, error] {
+	return history(ctx, r.supporting(func(b Backend) bool {
+		_, ok := b.(HistorySource)
+		return ok
+	}), addr, q, true)
+}
+
+func (r *Pool) Events(ctx context.Context, contract string, q EventQuery) iter.Seq2[ContractEvent, error] {
+	return events(ctx, r.supporting(func(b Backend) bool {
+		_, ok := b.(EventSource)
+		return ok
+	}), contract, q)
+}
+
+func (r *Pool) supporting(fn func(b Backend) bool) Backend {
+	eps := r.order()
+	for _, ep := range eps {
+		if fn(ep.Backend) {
+			return ep.Backend
+		}
+	}
+	return eps[0].Backend
+}
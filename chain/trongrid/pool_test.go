@@ -0,0 +1,203 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testBackend fails or slows down calls on demand.
+type testBackend struct {
+	Backend
+	fail  atomic.Bool
+	delay atomic.Int64
+	calls atomic.Int32
+
+	// error returned by Broadcast
+	broadcastErr error
+}
+
+func newTestBackend(t *testing.T) *testBackend {
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	return &testBackend{Backend: client}
+}
+
+func (r *testBackend) call() error {
+	r.calls.Add(1)
+	time.Sleep(time.Duration(r.delay.Load()))
+	if r.fail.Load() {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (r *testBackend) Now(ctx context.Context) (*Block, error) {
+	if err := r.call(); err != nil {
+		return nil, err
+	}
+	return r.Backend.Now(ctx)
+}
+
+func (r *testBackend) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	if err := r.call(); err != nil {
+		return nil, err
+	}
+	return r.Backend.BlockByNum(ctx, num)
+}
+
+func (r *testBackend) Broadcast(ctx context.Context, tx Tx) (string, error) {
+	if err := r.call(); err != nil {
+		return "", err
+	}
+	if r.broadcastErr != nil {
+		return "", r.broadcastErr
+	}
+	return tx.TxID, nil
+}
+
+func TestPoolFailover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a, b := newTestBackend(t), newTestBackend(t)
+	pool, err := NewPool([]Backend{a, b}, WithBreaker(2, time.Hour))
+	assert.NoError(t, err)
+
+	// there is nothing to fail over to without backends
+	_, err = NewPool(nil)
+	assert.Error(t, err)
+
+	a.fail.Store(true)
+	for range 3 {
+		blk, err := pool.BlockByNum(ctx, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, blockID(100), blk.BlockID)
+	}
+
+	// the breaker of a opened after two failures
+	assert.Equal(t, int32(2), a.calls.Load())
+	assert.Equal(t, int32(3), b.calls.Load())
+	stats := pool.Stats()
+	assert.True(t, stats[0].Open)
+	assert.False(t, stats[1].Open)
+
+	// open backends are still tried as a last resort
+	b.fail.Store(true)
+	a.fail.Store(false)
+	_, err = pool.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.False(t, pool.Stats()[0].Open)
+
+	a.fail.Store(true)
+	_, err = pool.BlockByNum(ctx, 100)
+	assert.ErrorContains(t, err, "all 2 backends failed")
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestPoolLatency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a, b := newTestBackend(t), newTestBackend(t)
+	a.delay.Store(int64(20 * time.Millisecond))
+	pool, err := NewPool([]Backend{a, b})
+	assert.NoError(t, err)
+	sticky := pool.Sticky()
+
+	// the sticky pool pins a before latencies are known
+	_, err = sticky.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), a.calls.Load())
+
+	pool.Check(ctx)
+	assert.Greater(t, pool.Stats()[0].Latency, pool.Stats()[1].Latency)
+
+	_, err = pool.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), b.calls.Load())
+
+	_, err = sticky.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), a.calls.Load())
+
+	// once a fails the sticky pool moves to b and stays there
+	a.fail.Store(true)
+	_, err = sticky.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	a.fail.Store(false)
+	_, err = sticky.BlockByNum(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), a.calls.Load())
+	assert.Equal(t, int32(4), b.calls.Load())
+}
+
+func TestPoolBroadcast(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	a, b, c := newTestBackend(t), newTestBackend(t), newTestBackend(t)
+	a.broadcastErr = &BroadcastError{Code: "SIGERROR", Message: "validate signature error"}
+
+	// rejected transactions are not retried and do not count as failures
+	pool, err := NewPool([]Backend{a, b, c}, WithBreaker(1, time.Hour))
+	assert.NoError(t, err)
+	_, err = pool.Broadcast(ctx, Tx{TxID: "t1"})
+	var broadcastErr *BroadcastError
+	assert.True(t, errors.As(err, &broadcastErr))
+	assert.Equal(t, int32(0), b.calls.Load())
+	assert.Equal(t, 0, pool.Stats()[0].Failures)
+
+	// a backend that already knows the transaction accepted it
+	a.broadcastErr = &BroadcastError{Code: "DUP_TRANSACTION_ERROR"}
+	hash, err := pool.Broadcast(ctx, Tx{TxID: "t1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", hash)
+	assert.Equal(t, int32(0), b.calls.Load())
+
+	b.fail.Store(true)
+	pool, err = NewPool([]Backend{a, b, c}, WithBreaker(1, time.Hour), WithBroadcastAll())
+	assert.NoError(t, err)
+	hash, err = pool.Broadcast(ctx, Tx{TxID: "t1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", hash)
+	assert.Equal(t, int32(3), a.calls.Load())
+	assert.Equal(t, int32(1), b.calls.Load())
+	assert.Equal(t, int32(1), c.calls.Load())
+
+	// b is skipped while its breaker is open
+	_, err = pool.Broadcast(ctx, Tx{TxID: "t2"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), b.calls.Load())
+	assert.Equal(t, int32(2), c.calls.Load())
+}
+
+func TestPoolWatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodeA, a := newFakeNode(t)
+	nodeB, b := newFakeNode(t)
+	for _, node := range []*fakeNode{nodeA, nodeB} {
+		node.addBlock(100, nil, nil)
+		node.addBlock(101, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+		node.addBlock(102, nil, nil)
+	}
+	failing := &testBackend{Backend: a}
+	failing.fail.Store(true)
+
+	pool, err := NewPool([]Backend{failing, b})
+	assert.NoError(t, err)
+	watcher := Watch(ctx, pool.Sticky(), &memCursor{curr: 101}, func(hash, sender, receiver string) bool {
+		return receiver == alice
+	}, WithInterval(10*time.Millisecond))
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+}
@@ -0,0 +1,292 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting for the rate limit of a Client.
+type Priority int
+
+const (
+	// PriorityLow is used by watchers and scanners polling blocks.
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+
+	// PriorityHigh is always used for broadcasts.
+	PriorityHigh
+)
+
+type priorityKey struct{}
+
+// WithPriority makes requests made with ctx wait for the rate limit with
+// priority p. Requests without one are PriorityNormal.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priority(ctx context.Context) (Priority, bool) {
+	p, ok := ctx.Value(priorityKey{}).(Priority)
+	return p, ok
+}
+
+// ErrQuotaExceeded is returned once every api key used its daily quota.
+var ErrQuotaExceeded = errors.New("daily quota of all api keys exceeded")
+
+// WithRateLimit limits requests to qps per api key with bursts of up to burst
+// requests. Requests wait for their turn, higher priorities first.
+func WithRateLimit(qps float64, burst int) ClientOption {
+	return func(r *Client) {
+		r.limits.qps = qps
+		r.limits.burst = burst
+	}
+}
+
+// WithAPIKeys rotates requests over keys in addition to the key passed to
+// New. A key answered with 429 is paused as long as Retry-After says and the
+// request is sent again with the next key.
+func WithAPIKeys(keys ...string) ClientOption {
+	return func(r *Client) {
+		r.limits.keys = append(r.limits.keys, keys...)
+	}
+}
+
+// WithDailyQuota limits every api key to n requests per utc day, after which
+// the next key is used.
+func WithDailyQuota(n int) ClientOption {
+	return func(r *Client) {
+		r.limits.quota = n
+	}
+}
+
+type limits struct {
+	qps   float64
+	burst int
+	keys  []string
+	quota int
+}
+
+// KeyUsage describes the usage of an api key of a Client.
+type KeyUsage struct {
+	// Last four characters of the key.
+	Key string
+
+	// Requests sent with the key today.
+	Used int
+
+	// Zero unless the key was answered with 429.
+	PausedUntil time.Time
+}
+
+// KeyUsage reports the usage of every api key, nil without WithRateLimit,
+// WithAPIKeys or WithDailyQuota.
+func (r *Client) KeyUsage() []KeyUsage {
+	if r.limiter == nil {
+		return nil
+	}
+
+	r.limiter.mu.Lock()
+	defer r.limiter.mu.Unlock()
+
+	usage := make([]KeyUsage, len(r.limiter.keys))
+	for i, k := range r.limiter.keys {
+		usage[i] = KeyUsage{
+			Key:         k.key[max(0, len(k.key)-4):],
+			Used:        k.used,
+			PausedUntil: k.pausedUntil,
+		}
+	}
+	return usage
+}
+
+// limiter is a http.RoundTripper picking the api key of every request and
+// waiting for its token bucket.
+type limiter struct {
+	next http.RoundTripper
+
+	mu    sync.Mutex
+	keys  []*apiKey
+	turn  int
+	qps   float64
+	burst float64
+	quota int
+
+	// number of requests waiting by priority
+	waiting map[Priority]int
+}
+
+type apiKey struct {
+	key         string
+	tokens      float64
+	refilled    time.Time
+	day         int
+	used        int
+	pausedUntil time.Time
+}
+
+func newLimiter(next http.RoundTripper, keys []string, l limits) *limiter {
+	self := &limiter{
+		next:    next,
+		qps:     l.qps,
+		burst:   float64(max(l.burst, 1)),
+		quota:   l.quota,
+		waiting: make(map[Priority]int),
+	}
+	now := time.Now()
+	for _, key := range keys {
+		self.keys = append(self.keys, &apiKey{key: key, tokens: self.burst, refilled: now})
+	}
+	return self
+}
+
+func (r *limiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	p, _ := priority(req.Context())
+	body := req.Body
+	for {
+		key, err := r.acquire(req.Context(), p)
+		if err != nil {
+			return nil, err
+		}
+
+		out := req.Clone(req.Context())
+		out.Body = body
+		out.Header.Set("TRON-PRO-API-KEY", key.key)
+
+		resp, err := r.next.RoundTrip(out)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		r.pause(key, retryAfter(resp.Header))
+		resent := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if !r.available() || !resent {
+			// leave waiting for Retry-After to the retrying client
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if req.GetBody != nil {
+			body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// acquire waits until a key has quota and a token left.
+func (r *limiter) acquire(ctx context.Context, p Priority) (*apiKey, error) {
+	for {
+		r.mu.Lock()
+		key, wait, err := r.take(p, time.Now())
+		if key != nil || err != nil {
+			r.mu.Unlock()
+			return key, err
+		}
+		r.waiting[p]++
+		r.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+
+		r.mu.Lock()
+		r.waiting[p]--
+		r.mu.Unlock()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// take takes a token of the next usable key in turn, otherwise it returns
+// how long to wait before trying again.
+func (r *limiter) take(p Priority, now time.Time) (*apiKey, time.Duration, error) {
+	wait := 10 * time.Millisecond
+	if r.qps > 0 {
+		wait = time.Duration(float64(time.Second) / r.qps)
+	}
+	for waiting, n := range r.waiting {
+		if waiting > p && n > 0 {
+			return nil, wait, nil
+		}
+	}
+
+	day := int(now.Unix() / 86400)
+	exhausted := 0
+	for i := range r.keys {
+		k := r.keys[(r.turn+i)%len(r.keys)]
+		if k.day != day {
+			k.day = day
+			k.used = 0
+		}
+		if r.quota > 0 && k.used >= r.quota {
+			exhausted++
+			continue
+		}
+		if now.Before(k.pausedUntil) {
+			wait = min(wait, k.pausedUntil.Sub(now))
+			continue
+		}
+		if r.qps > 0 {
+			k.tokens = min(r.burst, k.tokens+now.Sub(k.refilled).Seconds()*r.qps)
+			k.refilled = now
+			if k.tokens < 1 {
+				wait = min(wait, time.Duration((1-k.tokens)/r.qps*float64(time.Second)))
+				continue
+			}
+			k.tokens--
+		}
+
+		k.used++
+		r.turn = (r.turn + i + 1) % len(r.keys)
+		return k, 0, nil
+	}
+	if exhausted == len(r.keys) {
+		return nil, 0, ErrQuotaExceeded
+	}
+	return nil, wait, nil
+}
+
+func (r *limiter) pause(k *apiKey, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k.pausedUntil = time.Now().Add(d)
+}
+
+// available reports whether any key is not paused and has quota left.
+func (r *limiter) available() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, k := range r.keys {
+		if !now.Before(k.pausedUntil) && (r.quota == 0 || k.used < r.quota) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header, which is either seconds or a
+// date. Defaults to a second.
+func retryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return time.Second
+}
@@ -0,0 +1,114 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		key := req.Header.Get("TRON-PRO-API-KEY")
+		keys = append(keys, key)
+		if key == "key-a" {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var b Block
+		b.BlockHeader.RawData.Number = 100
+		json.NewEncoder(w).Encode(b)
+	}))
+	t.Cleanup(server.Close)
+
+	client := New(chain.Mainnet, "key-a", WithBaseURL(server.URL), WithAPIKeys("key-b"))
+	for range 2 {
+		b, err := client.Now(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint(100), b.BlockHeader.RawData.Number)
+	}
+	assert.Equal(t, []string{"key-a", "key-b", "key-b"}, keys)
+
+	usage := client.KeyUsage()
+	assert.Equal(t, "ey-a", usage[0].Key)
+	assert.Equal(t, 1, usage[0].Used)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), usage[0].PausedUntil, 5*time.Second)
+	assert.Equal(t, 2, usage[1].Used)
+	assert.True(t, usage[1].PausedUntil.IsZero())
+}
+
+func TestDailyQuota(t *testing.T) {
+	t.Parallel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	client = New(chain.Mainnet, "", WithBaseURL(client.baseURL), WithAPIKeys("key-a", "key-b"), WithDailyQuota(1))
+
+	for range 2 {
+		_, err := client.Now(context.Background())
+		assert.NoError(t, err)
+	}
+	_, err := client.Now(context.Background())
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+}
+
+func TestRateLimitPriority(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := newLimiter(nil, []string{""}, limits{qps: 20, burst: 1})
+
+	// use up the burst
+	_, err := l.acquire(ctx, PriorityNormal)
+	assert.NoError(t, err)
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	acquire := func(p Priority) {
+		wg.Go(func() {
+			_, err := l.acquire(ctx, p)
+			assert.NoError(t, err)
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+		})
+	}
+
+	acquire(PriorityLow)
+	assert.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.waiting[PriorityLow] == 1
+	}, time.Second, time.Millisecond)
+	acquire(PriorityHigh)
+	wg.Wait()
+
+	assert.Equal(t, []Priority{PriorityHigh, PriorityLow}, order)
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	assert.Equal(t, time.Second, retryAfter(h))
+	h.Set("Retry-After", "120")
+	assert.Equal(t, 2*time.Minute, retryAfter(h))
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Hour), float64(retryAfter(h)), float64(2*time.Second))
+}
@@ -0,0 +1,65 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/joshuayildiz/wallet/cursor"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// ErrDeepReorg stops the watcher when a reorg reaches further back than the
+// blocks remembered by the cursor. Events of the orphaned blocks can not be
+// reverted, the cursor has to be rewound to before the fork by hand.
+var ErrDeepReorg = errors.New("reorg is deeper than the remembered blocks")
+
+// revert checks that b builds on the last processed block. If it does not,
+// events of the orphaned blocks are emitted again with StateReverted, newest
+// first, and the cursor is rewound to the last block both chains share.
+func (r *Watcher) revert(ctx context.Context, c cursor.Tracked, b *Block) (bool, error) {
+	num := b.BlockHeader.RawData.Number
+	if num == 0 {
+		return false, nil
+	}
+
+	prevID, _, ok := c.Block(num - 1)
+	if !ok || prevID == b.BlockHeader.RawData.ParentHash {
+		return false, nil
+	}
+
+	fork := num - 1
+	for {
+		id, _, ok := c.Block(fork)
+		if !ok {
+			return false, fmt.Errorf("reorg at block %d: %w", num, ErrDeepReorg)
+		}
+
+		nb, err := r.trongrid.BlockByNum(ctx, fork)
+		if err != nil {
+			return false, &fetchError{err: fmt.Errorf("fetching block %d: %w", fork, err)}
+		}
+		if nb.BlockID == id {
+			break
+		}
+		fork--
+	}
+
+	for n := num - 1; n > fork; n-- {
+		_, events, _ := c.Block(n)
+		for i := len(events) - 1; i >= 0; i-- {
+			e := events[i]
+			e.State = txevent.StateReverted
+			err := r.emit(ctx, e)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	err := c.Rewind(fork + 1)
+	if err != nil {
+		return false, fmt.Errorf("rewinding cursor: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,107 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/cursor"
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcherReorg(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	node.addBlock(101, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+	node.addBlock(102, []Tx{transferTx("t2", bob, alice, 2)}, []TxInfo{txInfo("t2", 0, "SUCCESS")})
+	node.addBlock(103, nil, nil)
+
+	c, err := cursor.NewFile(filepath.Join(t.TempDir(), "cursor.json"), 100, 10)
+	assert.NoError(t, err)
+
+	watcher := Watch(ctx, client, c, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond))
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == 103
+	}, time.Second, 5*time.Millisecond)
+
+	// 101 and 102 are replaced, t2 moves to 101. Looking for the fork
+	// point fails once and is retried.
+	node.fail(102, 1)
+	node.reorg(101, []Tx{transferTx("t2", bob, alice, 2)}, []TxInfo{txInfo("t2", 0, "SUCCESS")})
+	node.reorg(102, nil, nil)
+	node.reorg(103, nil, nil)
+	node.addBlock(104, nil, nil)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, txevent.StateReverted, e.State)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.StateReverted, e.State)
+	assert.Equal(t, uint(101), e.Block)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, txevent.StateConfirmed, e.State)
+	assert.Equal(t, uint(101), e.Block)
+
+	// stop the watcher before the cursor file is removed
+	cancel()
+	for range watcher.EventCh {
+	}
+}
+
+func TestWatcherDeepReorg(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, nil, nil)
+	node.addBlock(101, []Tx{transferTx("t1", bob, alice, 1)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+	node.addBlock(102, nil, nil)
+	node.addBlock(103, nil, nil)
+
+	// only one block is remembered
+	c, err := cursor.NewFile(filepath.Join(t.TempDir(), "cursor.json"), 100, 1)
+	assert.NoError(t, err)
+
+	watcher := Watch(ctx, client, c, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithPending(0))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range watcher.EventCh {
+		}
+	}()
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == 103
+	}, time.Second, 5*time.Millisecond)
+
+	node.reorg(101, nil, nil)
+	node.reorg(102, nil, nil)
+	node.reorg(103, nil, nil)
+	node.addBlock(104, nil, nil)
+
+	// the watcher and its pending tracker stop instead of panicking
+	<-done
+	assert.True(t, errors.Is(watcher.Err(), ErrDeepReorg))
+}
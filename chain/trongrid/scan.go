@@ -0,0 +1,200 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/joshuayildiz/wallet/cursor"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// Progress of a scan, Rate is in blocks per second.
+type Progress struct {
+	Block uint
+	Done  uint
+	Total uint
+	Rate  float64
+	ETA   time.Duration
+}
+
+type scanner struct {
+	parallelism int
+	checkpoint  cursor.Cursor
+	progress    func(Progress)
+	failed      bool
+}
+
+type ScanOption func(*scanner)
+
+// WithParallelism sets how many blocks are fetched at once, defaults to 4.
+// Events are yielded in block order regardless.
+func WithParallelism(n int) ScanOption {
+	return func(r *scanner) {
+		r.parallelism = max(n, 1)
+	}
+}
+
+// WithCheckpoint resumes the scan at c.Curr() and advances c after all
+// events of a block were yielded. A new checkpoint has to start at from. The
+// events of the block the scan stopped in are yielded again on resume.
+func WithCheckpoint(c cursor.Cursor) ScanOption {
+	return func(r *scanner) {
+		r.checkpoint = c
+	}
+}
+
+// WithProgress calls fn after every block.
+func WithProgress(fn func(Progress)) ScanOption {
+	return func(r *scanner) {
+		r.progress = fn
+	}
+}
+
+// WithScanFailed also yields transfers that did not succeed, like WithFailed
+// does for watchers.
+func WithScanFailed() ScanOption {
+	return func(r *scanner) {
+		r.failed = true
+	}
+}
+
+type scanResult struct {
+	events []txevent.E
+	err    error
+}
+
+// Scan yields transfers matching filter from the solidified blocks from to to,
+// both inclusive. Unlike a Watcher it does not poll and stops at to. The scan
+// stops at the first error, which is yielded. Blocks are fetched with
+// PriorityLow unless ctx carries a priority.
+func Scan(ctx context.Context, trongrid Backend, from, to uint, filter func(hash, sender, receiver string) bool, opts ...ScanOption) iter.Seq2[txevent.E, error] {
+	s := scanner{parallelism: 4}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if _, ok := priority(ctx); !ok {
+		ctx = WithPriority(ctx, PriorityLow)
+	}
+
+	return func(yield func(txevent.E, error) bool) {
+		start := from
+		if s.checkpoint != nil {
+			start = s.checkpoint.Curr()
+			if start < from {
+				yield(txevent.E{}, fmt.Errorf("checkpoint at %d is before %d", start, from))
+				return
+			}
+		}
+		if start > to {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// results in block order, the capacity bounds how far fetching runs
+		// ahead of the consumer
+		order := make(chan chan scanResult, s.parallelism)
+		go func() {
+			defer close(order)
+
+			sem := make(chan struct{}, s.parallelism)
+			for num := start; num <= to; num++ {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				ch := make(chan scanResult, 1)
+				select {
+				case order <- ch:
+				case <-ctx.Done():
+					return
+				}
+
+				go func() {
+					defer func() { <-sem }()
+					events, err := s.block(ctx, trongrid, num, filter)
+					ch <- scanResult{events, err}
+				}()
+			}
+		}()
+
+		began := time.Now()
+		num := start
+		for ch := range order {
+			res := <-ch
+			if res.err != nil {
+				yield(txevent.E{}, res.err)
+				return
+			}
+
+			for _, e := range res.events {
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			if s.checkpoint != nil {
+				err := s.checkpoint.Adv()
+				if err != nil {
+					yield(txevent.E{}, fmt.Errorf("advancing checkpoint: %w", err))
+					return
+				}
+			}
+
+			if s.progress != nil {
+				done, total := num-start+1, to-start+1
+				p := Progress{Block: num, Done: done, Total: total}
+				if elapsed := time.Since(began).Seconds(); elapsed > 0 {
+					p.Rate = float64(done) / elapsed
+					p.ETA = time.Duration(float64(total-done) / p.Rate * float64(time.Second))
+				}
+				s.progress(p)
+			}
+
+			if num == to {
+				return
+			}
+			num++
+		}
+
+		// order was closed early because ctx is done
+		yield(txevent.E{}, ctx.Err())
+	}
+}
+
+// block returns the events of block num, decoded like the watcher does.
+func (r *scanner) block(ctx context.Context, trongrid Backend, num uint, filter func(hash, sender, receiver string) bool) ([]txevent.E, error) {
+	b, err := trongrid.BlockByNum(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+	if b.BlockHeader.RawData.Number != num {
+		// nodes return an empty block for blocks that are not solidified yet
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+	infos, err := trongrid.TxInfoByBlockNum(ctx, num)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []txevent.E
+	collector := &Watcher{
+		trongrid: trongrid,
+		failed:   r.failed,
+		handler: func(ctx context.Context, e txevent.E) error {
+			events = append(events, e)
+			return nil
+		},
+	}
+	err = collector.doBlock(ctx, b, infos, filter)
+	if err != nil {
+		return nil, fmt.Errorf("scanning block %d: %w", num, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,87 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	node, client := newFakeNode(t)
+	for num := uint(100); num <= 120; num++ {
+		id := fmt.Sprintf("t%d", num)
+		node.addBlock(num, []Tx{transferTx(id, bob, alice, int(num))}, []TxInfo{txInfo(id, 0, "SUCCESS")})
+	}
+
+	checkpoint := &memCursor{curr: 105}
+	var progress []Progress
+	var blocks []uint
+	for e, err := range Scan(ctx, client, 105, 115, func(hash, sender, receiver string) bool {
+		return true
+	}, WithParallelism(3), WithCheckpoint(checkpoint), WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	})) {
+		assert.NoError(t, err)
+		assert.Equal(t, txevent.StateConfirmed, e.State)
+		blocks = append(blocks, e.Block)
+	}
+
+	assert.Equal(t, []uint{105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115}, blocks)
+	assert.Len(t, progress, 11)
+	assert.Equal(t, uint(11), progress[10].Done)
+	assert.Equal(t, uint(11), progress[10].Total)
+	assert.Equal(t, uint(116), checkpoint.curr)
+}
+
+func TestScanResume(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	node, client := newFakeNode(t)
+	for num := uint(100); num <= 110; num++ {
+		id := fmt.Sprintf("t%d", num)
+		node.addBlock(num, []Tx{transferTx(id, bob, alice, int(num))}, []TxInfo{txInfo(id, 0, "SUCCESS")})
+	}
+
+	checkpoint := &memCursor{curr: 100}
+	filter := func(hash, sender, receiver string) bool {
+		return true
+	}
+
+	// stop in the middle
+	for e, err := range Scan(ctx, client, 100, 110, filter, WithCheckpoint(checkpoint)) {
+		assert.NoError(t, err)
+		if e.Block == 104 {
+			break
+		}
+	}
+	assert.Equal(t, uint(104), checkpoint.curr)
+
+	var blocks []uint
+	for e, err := range Scan(ctx, client, 100, 110, filter, WithCheckpoint(checkpoint)) {
+		assert.NoError(t, err)
+		blocks = append(blocks, e.Block)
+	}
+	assert.Equal(t, []uint{104, 105, 106, 107, 108, 109, 110}, blocks)
+
+	// blocks past the solidified head are an error
+	var errs int
+	for _, err := range Scan(ctx, client, 110, 111, filter) {
+		if err != nil {
+			errs++
+		}
+	}
+	assert.Equal(t, 1, errs)
+
+	for _, err := range Scan(ctx, client, 100, 110, filter, WithCheckpoint(&memCursor{curr: 50})) {
+		assert.Error(t, err)
+	}
+}
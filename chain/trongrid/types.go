@@ -1,11 +1,17 @@
 package trongrid
 
+import (
+	"encoding/json"
+	"slices"
+)
+
 // todo: check what this should look like
 type Block struct {
 	BlockID     string `json:"blockID"`
 	BlockHeader struct {
 		RawData struct {
 			Number         uint   `json:"number"`
+			Timestamp      int64  `json:"timestamp"`
 			ParentHash     string `json:"parentHash"`
 			TxTrieRoot     string `json:"txTrieRoot"`
 			WitnessAddress string `json:"witness_address"`
@@ -15,18 +21,78 @@ type Block struct {
 }
 
 type Tx struct {
-	RawData struct {
-		Contract      []Contract `json:"contract"`
-		Expiration    uint       `json:"expiration"`
-		RefBlockBytes string     `json:"ref_block_bytes"`
-		RefBlockHash  string     `json:"ref_block_hash"`
-		Timestamp     uint       `json:"timestamp"`
-		FeeLimit      uint       `json:"fee_limit"`
-	} `json:"raw_data"`
-	RawDataHex string   `json:"raw_data_hex"`
-	TxID       string   `json:"txID"`
-	Visible    bool     `json:"visible"`
-	Signature  []string `json:"signature"`
+	RawData    TxRawData `json:"raw_data"`
+	RawDataHex string    `json:"raw_data_hex"`
+	TxID       string    `json:"txID"`
+	Visible    bool      `json:"visible"`
+	Signature  []string  `json:"signature"`
+	Ret        []TxRet   `json:"ret,omitempty"`
+
+	// raw_data exactly as returned by the node. RawData only knows a few
+	// contract types, so this is sent back instead to not lose any fields.
+	rawData json.RawMessage
+}
+
+type TxRet struct {
+	Ret         string `json:"ret,omitempty"`
+	ContractRet string `json:"contractRet,omitempty"`
+	Fee         int    `json:"fee,omitempty"`
+}
+
+type TxRawData struct {
+	Contract      []Contract `json:"contract"`
+	Expiration    uint       `json:"expiration"`
+	RefBlockBytes string     `json:"ref_block_bytes"`
+	RefBlockHash  string     `json:"ref_block_hash"`
+	Timestamp     uint       `json:"timestamp"`
+	FeeLimit      uint       `json:"fee_limit"`
+	Data          string     `json:"data,omitempty"`
+}
+
+// clone copies the slices of r, which are shared otherwise.
+func (r Tx) clone() Tx {
+	r.RawData.Contract = slices.Clone(r.RawData.Contract)
+	r.Signature = slices.Clone(r.Signature)
+	r.Ret = slices.Clone(r.Ret)
+	r.rawData = slices.Clone(r.rawData)
+	return r
+}
+
+func (r *Tx) UnmarshalJSON(b []byte) error {
+	type tx Tx
+	var data struct {
+		tx
+		RawData json.RawMessage `json:"raw_data"`
+	}
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+
+	*r = Tx(data.tx)
+	if len(data.RawData) == 0 || string(data.RawData) == "null" {
+		return nil
+	}
+
+	err = json.Unmarshal(data.RawData, &r.RawData)
+	if err != nil {
+		return err
+	}
+	r.rawData = data.RawData
+	return nil
+}
+
+func (r Tx) MarshalJSON() ([]byte, error) {
+	type tx Tx
+	if r.rawData == nil {
+		return json.Marshal(tx(r))
+	}
+
+	data := struct {
+		tx
+		RawData json.RawMessage `json:"raw_data"`
+	}{tx(r), r.rawData}
+	return json.Marshal(data)
 }
 
 type Contract struct {
@@ -38,9 +104,43 @@ type Contract struct {
 			ToAddress       string `json:"to_address"`
 			Data            string `json:"data"`
 			ContractAddress string `json:"contract_address"`
+			CallValue       int    `json:"call_value,omitempty"`
+			CallTokenValue  int    `json:"call_token_value,omitempty"`
+			TokenID         int    `json:"token_id,omitempty"`
+			AssetName       string `json:"asset_name,omitempty"`
 		} `json:"value"`
 	} `json:"parameter"`
-	Type string `json:"type"`
+	Type         string `json:"type"`
+	PermissionID int    `json:"Permission_id,omitempty"`
+}
+
+type Permission struct {
+	Type           string          `json:"type,omitempty"`
+	ID             int             `json:"id,omitempty"`
+	PermissionName string          `json:"permission_name"`
+	Threshold      int             `json:"threshold"`
+	Operations     string          `json:"operations,omitempty"`
+	Keys           []PermissionKey `json:"keys"`
+}
+
+type PermissionKey struct {
+	Address string `json:"address"`
+	Weight  int    `json:"weight"`
+}
+
+type SignWeight struct {
+	Result struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	Permission    Permission `json:"permission"`
+	ApprovedList  []string   `json:"approved_list"`
+	CurrentWeight int        `json:"current_weight"`
+}
+
+// Enough reports whether the approved keys reach the permission threshold.
+func (r *SignWeight) Enough() bool {
+	return r.Result.Code == "ENOUGH_PERMISSION"
 }
 
 type TxInfo struct {
@@ -50,13 +150,10 @@ type TxInfo struct {
 	ContractAddress string   `json:"contract_address"`
 	Fee             int      `json:"fee"`
 	ID              string   `json:"id"`
-	Log             []struct {
-		Address string   `json:"address"`
-		Data    string   `json:"data"`
-		Topics  []string `json:"topics"`
-	} `json:"log"`
-	Receipt struct {
+	Log             []TxLog  `json:"log"`
+	Receipt         struct {
 		EnergyFee         int    `json:"energy_fee"`
+		NetFee            int    `json:"net_fee"`
 		EnergyUsageTotal  int    `json:"energy_usage_total"`
 		NetUsage          int    `json:"net_usage"`
 		OriginEnergyUsage int    `json:"origin_energy_usage"`
@@ -64,6 +161,22 @@ type TxInfo struct {
 	} `json:"receipt"`
 }
 
+// clone copies the slices of r, which are shared otherwise.
+func (r TxInfo) clone() TxInfo {
+	r.ContractResult = slices.Clone(r.ContractResult)
+	r.Log = slices.Clone(r.Log)
+	for i, l := range r.Log {
+		r.Log[i].Topics = slices.Clone(l.Topics)
+	}
+	return r
+}
+
+type TxLog struct {
+	Address string   `json:"address"`
+	Data    string   `json:"data"`
+	Topics  []string `json:"topics"`
+}
+
 type TriggerConstContract struct {
 	Result struct {
 		Code    string `json:"code"`
@@ -83,3 +196,103 @@ type TriggerSmartContract struct {
 	} `json:"result"`
 	Transaction Tx `json:"transaction"`
 }
+
+// AccountTx is a transaction as returned by the v1 accounts api.
+type AccountTx struct {
+	Tx
+	BlockNumber      uint
+	BlockTimestamp   int64
+	EnergyFee        int
+	EnergyUsageTotal int
+	NetFee           int
+}
+
+// UnmarshalJSON is needed since Tx would otherwise shadow the other fields.
+func (r *AccountTx) UnmarshalJSON(b []byte) error {
+	err := json.Unmarshal(b, &r.Tx)
+	if err != nil {
+		return err
+	}
+
+	var data struct {
+		BlockNumber      uint  `json:"blockNumber"`
+		BlockTimestamp   int64 `json:"block_timestamp"`
+		EnergyFee        int   `json:"energy_fee"`
+		EnergyUsageTotal int   `json:"energy_usage_total"`
+		NetFee           int   `json:"net_fee"`
+	}
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+
+	r.BlockNumber = data.BlockNumber
+	r.BlockTimestamp = data.BlockTimestamp
+	r.EnergyFee = data.EnergyFee
+	r.EnergyUsageTotal = data.EnergyUsageTotal
+	r.NetFee = data.NetFee
+	return nil
+}
+
+type AccountTxPage struct {
+	Data []AccountTx `json:"data"`
+	Meta PageMeta    `json:"meta"`
+}
+
+type TRC20Transfer struct {
+	TransactionID string `json:"transaction_id"`
+	TokenInfo     struct {
+		Symbol   string `json:"symbol"`
+		Address  string `json:"address"`
+		Decimals int    `json:"decimals"`
+		Name     string `json:"name"`
+	} `json:"token_info"`
+	BlockTimestamp int64  `json:"block_timestamp"`
+	From           string `json:"from"`
+	To             string `json:"to"`
+	Type           string `json:"type"`
+	Value          string `json:"value"`
+}
+
+type TRC20TransferPage struct {
+	Data []TRC20Transfer `json:"data"`
+	Meta PageMeta        `json:"meta"`
+}
+
+// PageMeta carries the fingerprint of the next page, empty on the last one.
+type PageMeta struct {
+	At          int64  `json:"at"`
+	Fingerprint string `json:"fingerprint"`
+	PageSize    int    `json:"page_size"`
+}
+
+// ContractEvent is a decoded contract event as returned by the v1 events api.
+// Result holds the arguments by position and by name.
+type ContractEvent struct {
+	BlockNumber           uint              `json:"block_number"`
+	BlockTimestamp        int64             `json:"block_timestamp"`
+	CallerContractAddress string            `json:"caller_contract_address"`
+	ContractAddress       string            `json:"contract_address"`
+	EventIndex            int               `json:"event_index"`
+	EventName             string            `json:"event_name"`
+	Event                 string            `json:"event"`
+	Result                map[string]string `json:"result"`
+	ResultType            map[string]string `json:"result_type"`
+	TransactionID         string            `json:"transaction_id"`
+}
+
+type ContractEventPage struct {
+	Data []ContractEvent `json:"data"`
+	Meta PageMeta        `json:"meta"`
+}
+
+// Log is a TxLog together with where it was emitted.
+type Log struct {
+	TxLog
+	Block     uint
+	BlockHash string
+	TxID      string
+
+	// Index as reported by the source, json-rpc counts logs per block.
+	LogIndex int
+}
@@ -0,0 +1,81 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// The usdt helpers work with any Backend, *Client has them as methods too.
+
+func USDTBalance(ctx context.Context, b Backend, addr string) (uint, error) {
+	values, err := b.CallConstant(ctx, addr, usdtContractAddr(b.Network()), trc20BalanceOf, addr)
+	if err != nil {
+		return 0, fmt.Errorf("getting usdt balance of addr %s: %w", addr, err)
+	}
+
+	balance := values[0].(*big.Int)
+	if !balance.IsUint64() || uint64(uint(balance.Uint64())) != balance.Uint64() {
+		return 0, fmt.Errorf("usdt balance of addr %s: %s overflows uint", addr, balance)
+	}
+
+	return uint(balance.Uint64()), nil
+}
+
+func SendUSDT(ctx context.Context, b Backend, from, to string, amt uint) (*Tx, error) {
+	tx, err := b.TriggerContract(ctx, Trigger{
+		From:     from,
+		Contract: usdtContractAddr(b.Network()),
+		Method:   trc20Transfer,
+		Args:     []any{to, amt},
+		FeeLimit: 10_000_000, // 10 trx
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sending usdt: %w", err)
+	}
+
+	return tx, nil
+}
+
+// USDTAllowance returns how much spender may transfer from owner. Unlimited
+// approvals are common, so the result does not fit an uint.
+func USDTAllowance(ctx context.Context, b Backend, owner, spender string) (*big.Int, error) {
+	values, err := b.CallConstant(ctx, owner, usdtContractAddr(b.Network()), trc20Allowance, owner, spender)
+	if err != nil {
+		return nil, fmt.Errorf("getting usdt allowance of %s for %s: %w", owner, spender, err)
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+// ApproveUSDT lets spender transfer up to amt from owner.
+func ApproveUSDT(ctx context.Context, b Backend, owner, spender string, amt *big.Int) (*Tx, error) {
+	tx, err := b.TriggerContract(ctx, Trigger{
+		From:     owner,
+		Contract: usdtContractAddr(b.Network()),
+		Method:   trc20Approve,
+		Args:     []any{spender, amt},
+		FeeLimit: 10_000_000, // 10 trx
+	})
+	if err != nil {
+		return nil, fmt.Errorf("approving usdt: %w", err)
+	}
+
+	return tx, nil
+}
+
+// TransferFromUSDT moves amt from an owner that approved spender.
+func TransferFromUSDT(ctx context.Context, b Backend, spender, from, to string, amt uint) (*Tx, error) {
+	tx, err := b.TriggerContract(ctx, Trigger{
+		From:     spender,
+		Contract: usdtContractAddr(b.Network()),
+		Method:   trc20TransferFrom,
+		Args:     []any{from, to, amt},
+		FeeLimit: 10_000_000, // 10 trx
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transferring usdt from %s: %w", from, err)
+	}
+
+	return tx, nil
+}
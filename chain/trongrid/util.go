@@ -1,54 +1,36 @@
 package trongrid
 
 import (
-	"bytes"
-	"crypto/sha256"
-	"encoding/hex"
+	"fmt"
+	"math/big"
 
-	"github.com/btcsuite/btcutil/base58"
-	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/tronaddr"
 )
 
-func decodeTransferAddr(value string) string {
-	addrBytes, err := hex.DecodeString(value)
+func decodeTransferAddr(value string) (string, error) {
+	addr, err := tronaddr.ParseHex(value)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-
-	first := sha256.Sum256(addrBytes)
-	second := sha256.Sum256(first[:])
-	checksum := second[:4]
-
-	both := append(addrBytes, checksum...)
-	encoded := base58.Encode(both)
-
-	return encoded
+	return addr.String(), nil
 }
 
-func decodeTopicAddr(net chain.Network, value string) string {
-	last40 := value[24:]
-	addrBytes, err := hex.DecodeString(last40)
+func decodeTopicAddr(value string) (string, error) {
+	if len(value) != 64 {
+		return "", fmt.Errorf("%w: topic %q has wrong length", tronaddr.ErrInvalid, value)
+	}
+	addr, err := tronaddr.ParseEVM(value[24:])
 	if err != nil {
-		panic(err) // todo: should we panic here? figure that out
+		return "", err
 	}
+	return addr.String(), nil
+}
 
-	var networkedBuf bytes.Buffer
-	switch net {
-	case chain.Mainnet:
-		networkedBuf.WriteByte(0x41)
-	case chain.Testnet:
-		networkedBuf.WriteByte(0x41) // apparently shasta also uses Mainnet -_-
+// amount converts a token amount to the int of txevent.E. Amounts that do not
+// fit are reported as not ok, real usdt amounts always fit.
+func amount(amt *big.Int) (int, bool) {
+	if amt.Sign() < 0 || !amt.IsInt64() || int64(int(amt.Int64())) != amt.Int64() {
+		return 0, false
 	}
-	networkedBuf.Write(addrBytes)
-
-	networked := networkedBuf.Bytes()
-
-	first := sha256.Sum256(networked)
-	second := sha256.Sum256(first[:])
-	checksum := second[:4]
-
-	both := append(networked, checksum...)
-	encoded := base58.Encode(both)
-
-	return encoded
+	return int(amt.Int64()), true
 }
@@ -2,41 +2,263 @@ package trongrid
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"strconv"
+	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/joshuayildiz/wallet/abi"
 	"github.com/joshuayildiz/wallet/cursor"
+	"github.com/joshuayildiz/wallet/tronaddr"
 	"github.com/joshuayildiz/wallet/txevent"
 )
 
 type Watcher struct {
-	trongrid *Client
+	trongrid Backend
 	EventCh  chan txevent.E
+
+	// Receives logs of the events registered with WithEvents. Needs to be
+	// consumed alongside EventCh.
+	LogCh chan txevent.Log
+
+	// contract evm hex -> topic hex -> event
+	events map[string]map[string]*abi.Event
+
+	interval  time.Duration
+	approvals bool
+	failed    bool
+	handler   func(ctx context.Context, e txevent.E) error
+
+	// set by WatchAddrs
+	addrs *AddrSet
+
+	// next block to be processed, c.Curr() is only safe to call from watch
+	curr atomic.Uint64
+
+	// set by WithPending
+	pending *pendingTracker
+	wg      sync.WaitGroup
+
+	// events delivered from the current block, remembered by tracked cursors
+	delivered []txevent.E
+
+	// set by WithEventAPI
+	eventContracts []string
+
+	// set by WithLogSource
+	logSource    LogSource
+	logContracts []string
+
+	// why the watcher stopped on its own, see Err. stop ends the background
+	// parts in that case.
+	err  error
+	stop context.CancelFunc
+}
+
+// handlerError marks errors of the handler, those are retried instead of
+// stopping the watcher.
+type handlerError struct {
+	err error
+}
+
+func (r *handlerError) Error() string {
+	return fmt.Sprintf("handling event: %v", r.err)
+}
+
+func (r *handlerError) Unwrap() error {
+	return r.err
+}
+
+// fetchError marks failed requests to the node in the background parts of the
+// watcher, those are retried on the next poll instead of stopping it.
+type fetchError struct {
+	err error
+}
+
+func (r *fetchError) Error() string {
+	return r.err.Error()
+}
+
+func (r *fetchError) Unwrap() error {
+	return r.err
+}
+
+type WatchOption func(*Watcher)
+
+// WithEvents decodes logs of the given events emitted by contract and sends
+// them to LogCh. Like tronaddr.MustParse it panics if contract is not a valid
+// address, so it is meant for constants.
+func WithEvents(contract string, events ...*abi.Event) WatchOption {
+	return func(r *Watcher) {
+		addr := tronaddr.MustParse(contract)
+		key := hex.EncodeToString(addr.EVMBytes())
+
+		if r.events[key] == nil {
+			r.events[key] = make(map[string]*abi.Event)
+		}
+		for _, e := range events {
+			id := e.ID()
+			r.events[key][hex.EncodeToString(id[:])] = e
+		}
+	}
+}
+
+// WithInterval sets how often the node is polled for new blocks, defaults
+// to 3 seconds.
+func WithInterval(d time.Duration) WatchOption {
+	return func(r *Watcher) {
+		r.interval = d
+	}
+}
+
+// WithApprovals sends usdt Approval events to LogCh. The filter gets called
+// with the owner as sender and the spender as receiver. It takes precedence
+// over a usdt Approval event registered with WithEvents, so every approval is
+// delivered once.
+func WithApprovals() WatchOption {
+	return func(r *Watcher) {
+		r.approvals = true
+	}
+}
+
+// WithFailed also delivers transfers that did not succeed, e.g. reverted
+// usdt transfers. Their fee was still paid.
+func WithFailed() WatchOption {
+	return func(r *Watcher) {
+		r.failed = true
+	}
 }
 
-func Watch(ctx context.Context, trongrid *Client, c cursor.Cursor, filter func(hash, sender, receiver string) bool) *Watcher {
+// WithEventAPI makes the watcher poll the v1 events api for Transfer events
+// of contracts instead of fetching every block, which needs far fewer
+// requests. Only token transfers are seen that way: trx transfers, failed
+// transfers, approvals and WithEvents logs need full blocks. The backend has
+// to be an EventSource.
+func WithEventAPI(contracts ...string) WatchOption {
+	return func(r *Watcher) {
+		r.eventContracts = contracts
+	}
+}
+
+// WithHandler passes events to handler instead of EventCh. The cursor only
+// advances past a block once handler returned nil for all its events, failed
+// blocks are retried on the next poll. Events of a retried block can be
+// handled twice, so handler must be idempotent, e.g. by using E.ID.
+func WithHandler(handler func(ctx context.Context, e txevent.E) error) WatchOption {
+	return func(r *Watcher) {
+		r.handler = handler
+	}
+}
+
+// Watch delivers transfers matching filter from block c.Curr() on. If c is a
+// cursor.Tracked, reorgs are detected by the parent hash of each block and
+// events of orphaned blocks are emitted again with txevent.StateReverted. A
+// reorg deeper than the cursor remembers stops the watcher, see Err.
+// Polling uses PriorityLow unless ctx carries a priority.
+func Watch(ctx context.Context, trongrid Backend, c cursor.Cursor, filter func(hash, sender, receiver string) bool, opts ...WatchOption) *Watcher {
 	self := &Watcher{
 		trongrid: trongrid,
 		EventCh:  make(chan txevent.E),
+		LogCh:    make(chan txevent.Log),
+		events:   make(map[string]map[string]*abi.Event),
+		interval: 3 * time.Second,
 	}
+	for _, opt := range opts {
+		opt(self)
+	}
+
+	if _, ok := priority(ctx); !ok {
+		ctx = WithPriority(ctx, PriorityLow)
+	}
+	ctx, self.stop = context.WithCancel(ctx)
 
+	self.curr.Store(uint64(c.Curr()))
+	if self.pending != nil {
+		self.pending.filter = filter
+		self.wg.Go(func() {
+			self.pending.run(ctx)
+		})
+	}
 	go self.watch(ctx, c, filter)
 
 	return self
 }
 
+// WatchAddrs watches transfers from or to any address of addrs, which can be
+// changed while watching. Events carry their direction relative to addrs.
+func WatchAddrs(ctx context.Context, trongrid Backend, c cursor.Cursor, addrs *AddrSet, opts ...WatchOption) *Watcher {
+	opts = append(opts[:len(opts):len(opts)], func(r *Watcher) {
+		r.addrs = addrs
+	})
+	return Watch(ctx, trongrid, c, addrs.filter, opts...)
+}
+
+// Backfill delivers past transfers of addrs from block from on, e.g. for
+// newly created deposit addresses. If the watcher watches an address set,
+// addrs are added to it first so nothing falls between backfill and watcher.
+// Events of the block the watcher is working on may be delivered twice.
+//
+// Events are delivered like the watcher's own ones and may interleave with
+// them, logs of WithEvents are not backfilled. It must not be called after
+// the watcher stopped.
+func (r *Watcher) Backfill(ctx context.Context, from uint, addrs ...string) error {
+	set, err := NewAddrSet(addrs...)
+	if err != nil {
+		return err
+	}
+	if r.addrs != nil {
+		err = r.addrs.Add(addrs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	to := uint(r.curr.Load())
+
+	backfill := &Watcher{
+		trongrid:  r.trongrid,
+		EventCh:   r.EventCh,
+		LogCh:     r.LogCh,
+		approvals: r.approvals,
+		failed:    r.failed,
+		handler:   r.handler,
+		addrs:     r.addrs,
+	}
+	for num := from; num <= to; num++ {
+		b, err := r.trongrid.BlockByNum(ctx, num)
+		if err != nil {
+			return fmt.Errorf("fetching block %d: %w", num, err)
+		}
+
+		infos, err := r.trongrid.TxInfoByBlockNum(ctx, num)
+		if err != nil {
+			return fmt.Errorf("fetching tx infos of block %d: %w", num, err)
+		}
+
+		err = backfill.doBlock(ctx, b, infos, set.filter)
+		if err != nil {
+			return fmt.Errorf("backfilling block %d: %w", num, err)
+		}
+	}
+
+	return nil
+}
+
 func (r *Watcher) watch(ctx context.Context, c cursor.Cursor, filter func(hash, sender, receiver string) bool) {
+	tracked, _ := c.(cursor.Tracked)
+
 loop:
 	for {
 		select {
 		case <-ctx.Done():
 			break loop
 
-		case <-time.After(3 * time.Second):
+		case <-time.After(r.interval):
 			now, err := r.trongrid.Now(ctx)
-			if errors.Is(err, context.DeadlineExceeded) {
+			if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
 				break loop
 			} else if err != nil {
 				panic(fmt.Errorf("watcher: fetching now block: %w", err))
@@ -47,40 +269,102 @@ loop:
 				continue
 			}
 
+			if r.eventContracts != nil || r.logSource != nil {
+				var err error
+				if r.logSource != nil {
+					err = r.doLogRange(ctx, c, latest, filter)
+				} else {
+					err = r.doEventRange(ctx, c, now, filter)
+				}
+				var (
+					hErr *handlerError
+					fErr *fetchError
+				)
+				if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+					break loop
+				} else if errors.As(err, &hErr) || errors.As(err, &fErr) {
+					continue
+				} else if err != nil {
+					panic(fmt.Errorf("watcher: %w", err))
+				}
+				continue
+			}
+
 			for c.Curr() < latest {
 				b, err := r.trongrid.BlockByNum(ctx, c.Curr())
-				if errors.Is(err, context.DeadlineExceeded) {
+				if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
 					break loop
 				} else if err != nil {
 					break
 				}
 
-				err = r.doBlock(ctx, b, filter)
-				if errors.Is(err, context.DeadlineExceeded) {
+				var (
+					hErr *handlerError
+					fErr *fetchError
+				)
+				if tracked != nil {
+					rewound, err := r.revert(ctx, tracked, b)
+					if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+						break loop
+					} else if errors.As(err, &hErr) || errors.As(err, &fErr) {
+						break
+					} else if errors.Is(err, ErrDeepReorg) {
+						r.err = fmt.Errorf("watcher: %w", err)
+						break loop
+					} else if err != nil {
+						panic(fmt.Errorf("watcher: %w", err))
+					}
+					if rewound {
+						r.curr.Store(uint64(c.Curr()))
+						continue
+					}
+				}
+
+				infos, err := r.trongrid.TxInfoByBlockNum(ctx, c.Curr())
+				if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+					break loop
+				} else if err != nil {
+					break
+				}
+
+				r.delivered = nil
+				err = r.doBlock(ctx, b, infos, filter)
+				if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
 					break loop
+				} else if errors.As(err, &hErr) {
+					break
 				} else if err != nil {
 					panic(fmt.Errorf("watcher: %w", err))
 				}
 
-				err = c.Adv()
+				if tracked != nil {
+					err = tracked.AdvBlock(b.BlockID, r.delivered)
+				} else {
+					err = c.Adv()
+				}
 				if errors.Is(err, context.DeadlineExceeded) {
 					break loop
 				} else if err != nil {
 					panic(fmt.Errorf("advancing cursor: %w", err))
 				}
+				r.curr.Store(uint64(c.Curr()))
 			}
 		}
 	}
 
+	r.stop()
+	r.wg.Wait()
 	close(r.EventCh)
+	close(r.LogCh)
 }
 
-func (r *Watcher) doBlock(ctx context.Context, b *Block, filter func(hash, sender, receiver string) bool) error {
-	txInfoList, err := r.trongrid.TxInfoByBlockNum(ctx, b.BlockHeader.RawData.Number)
-	if err != nil {
-		return err
-	}
+// Err returns why the watcher stopped before its context was done, e.g.
+// ErrDeepReorg. It is only safe to call once EventCh is closed.
+func (r *Watcher) Err() error {
+	return r.err
+}
 
+func (r *Watcher) doBlock(ctx context.Context, b *Block, txInfoList []TxInfo, filter func(hash, sender, receiver string) bool) error {
 	txInfoMap := make(map[string]TxInfo, 0)
 	for _, i := range txInfoList {
 		txInfoMap[i.ID] = i
@@ -98,8 +382,14 @@ func (r *Watcher) doBlock(ctx context.Context, b *Block, filter func(hash, sende
 		switch first.Type {
 		case "TransferContract":
 			hash := tx.TxID
-			from := decodeTransferAddr(first.Parameter.Value.OwnerAddress)
-			to := decodeTransferAddr(first.Parameter.Value.ToAddress)
+			from, err := decodeTransferAddr(first.Parameter.Value.OwnerAddress)
+			if err != nil {
+				return fmt.Errorf("decoding sender of %s: %w", tx.TxID, err)
+			}
+			to, err := decodeTransferAddr(first.Parameter.Value.ToAddress)
+			if err != nil {
+				return fmt.Errorf("decoding receiver of %s: %w", tx.TxID, err)
+			}
 			amt := first.Parameter.Value.Amount
 
 			if !filter(hash, from, to) {
@@ -111,14 +401,20 @@ func (r *Watcher) doBlock(ctx context.Context, b *Block, filter func(hash, sende
 				return fmt.Errorf("tx info not found: %s", tx.TxID)
 			}
 
-			r.EventCh <- txevent.E{
-				Block:    b.BlockHeader.RawData.Number,
-				Currency: txevent.TRX,
-				Hash:     hash,
-				Sender:   from,
-				Receiver: to,
-				Amount:   amt,
-				Fee:      info.Fee,
+			status := txStatus(tx, info)
+			if !status.Success() && !r.failed {
+				continue
+			}
+
+			e := newEvent(b, tx, info)
+			e.Currency = txevent.TRX
+			e.Sender = from
+			e.Receiver = to
+			e.Amount = amt
+			e.Status = status
+			err = r.emit(ctx, e)
+			if err != nil {
+				return err
 			}
 
 		case "TriggerSmartContract":
@@ -127,12 +423,19 @@ func (r *Watcher) doBlock(ctx context.Context, b *Block, filter func(hash, sende
 				return fmt.Errorf("tx info not found: %s", tx.TxID)
 			}
 
-			if info.Receipt.Result != "SUCCESS" {
+			status := txStatus(tx, info)
+			if !status.Success() {
+				if r.failed {
+					err := r.doFailedCall(ctx, b, tx, info, status, filter)
+					if err != nil {
+						return err
+					}
+				}
 				continue
 			}
 
-			for _, l := range info.Log {
-				if l.Address != encodedUSDTContractAddr(r.trongrid.Net) {
+			for i, l := range info.Log {
+				if l.Address != encodedUSDTContractAddr(r.trongrid.Network()) {
 					continue
 				}
 
@@ -141,31 +444,309 @@ func (r *Watcher) doBlock(ctx context.Context, b *Block, filter func(hash, sende
 				}
 
 				encodedEvent := l.Topics[0]
+				if r.approvals && encodedEvent == encodedApprovalEvent {
+					err := r.doApproval(ctx, b, info, i, l, filter)
+					if err != nil {
+						return err
+					}
+					continue
+				}
 				if encodedEvent != encodedTransferEvent {
 					continue
 				}
 
 				hash := tx.TxID
-				from := decodeTopicAddr(r.trongrid.Net, l.Topics[1])
-				to := decodeTopicAddr(r.trongrid.Net, l.Topics[2])
-				amt, _ := strconv.ParseInt(l.Data, 16, 64)
+				from, err := decodeTopicAddr(l.Topics[1])
+				if err != nil {
+					return fmt.Errorf("decoding sender of %s: %w", tx.TxID, err)
+				}
+				to, err := decodeTopicAddr(l.Topics[2])
+				if err != nil {
+					return fmt.Errorf("decoding receiver of %s: %w", tx.TxID, err)
+				}
+				value, ok := new(big.Int).SetString(l.Data, 16)
+				if !ok {
+					return fmt.Errorf("decoding amount of %s: %q is not hex", tx.TxID, l.Data)
+				}
+				amt, ok := amount(value)
+				if !ok {
+					// not an amount usdt can hold, nothing was transferred
+					continue
+				}
 
 				if !filter(hash, from, to) {
 					continue
 				}
 
-				r.EventCh <- txevent.E{
-					Block:    b.BlockHeader.RawData.Number,
-					Currency: txevent.TRON_USDT,
-					Hash:     hash,
-					Sender:   from,
-					Receiver: to,
-					Amount:   int(amt),
-					Fee:      info.Fee,
+				e := newEvent(b, tx, info)
+				e.LogIndex = i
+				e.Currency = txevent.TRON_USDT
+				e.Contract = usdtContractAddr(r.trongrid.Network())
+				e.Sender = from
+				e.Receiver = to
+				e.Amount = amt
+				e.Status = status
+				err = r.emit(ctx, e)
+				if err != nil {
+					return err
 				}
 			}
+
+			err := r.doLogs(ctx, b, info)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+func (r *Watcher) emit(ctx context.Context, e txevent.E) error {
+	if r.addrs != nil {
+		e.Direction = r.direction(e.Sender, e.Receiver)
+	}
+
+	// only the watch goroutine emits events without a state
+	if e.State == "" {
+		e.State = txevent.StateConfirmed
+		r.delivered = append(r.delivered, e)
+
+		if r.pending != nil {
+			return r.pending.confirm(e.ID(), func() error {
+				return r.deliver(ctx, e)
+			})
+		}
+	}
+
+	return r.deliver(ctx, e)
+}
+
+func (r *Watcher) deliver(ctx context.Context, e txevent.E) error {
+	if r.handler == nil {
+		r.EventCh <- e
+		return nil
+	}
+
+	err := r.handler(ctx, e)
+	if err != nil {
+		return &handlerError{err: err}
+	}
+	return nil
+}
+
+func (r *Watcher) direction(sender, receiver string) txevent.Direction {
+	out, in := r.addrs.Contains(sender), r.addrs.Contains(receiver)
+	switch {
+	case in && out:
+		return txevent.DirectionSelf
+	case out:
+		return txevent.DirectionOut
+	default:
+		return txevent.DirectionIn
+	}
+}
+
+// doFailedCall recovers usdt transfers from the call data, failed calls do
+// not emit any logs.
+func (r *Watcher) doFailedCall(ctx context.Context, b *Block, tx Tx, info TxInfo, status txevent.Status, filter func(hash, sender, receiver string) bool) error {
+	value := tx.RawData.Contract[0].Parameter.Value
+
+	usdt := tronaddr.MustParse(usdtContractAddr(r.trongrid.Network()))
+	if value.ContractAddress != usdt.Hex() {
+		return nil
+	}
+
+	data, err := hex.DecodeString(value.Data)
+	if err != nil || len(data) < 4 {
+		return nil
+	}
+
+	from, err := decodeTransferAddr(value.OwnerAddress)
+	if err != nil {
+		return fmt.Errorf("decoding sender of %s: %w", tx.TxID, err)
+	}
+
+	var (
+		to  tronaddr.Address
+		amt *big.Int
+	)
+	switch [4]byte(data[:4]) {
+	case trc20Transfer.Selector():
+		args, err := trc20Transfer.UnpackInput(data)
+		if err != nil {
+			return nil
+		}
+		to, amt = args[0].(tronaddr.Address), args[1].(*big.Int)
+
+	case trc20TransferFrom.Selector():
+		args, err := trc20TransferFrom.UnpackInput(data)
+		if err != nil {
+			return nil
+		}
+		from = args[0].(tronaddr.Address).String()
+		to, amt = args[1].(tronaddr.Address), args[2].(*big.Int)
+
+	default:
+		return nil
+	}
+
+	amtInt, ok := amount(amt)
+	if !ok {
+		// more than usdt can hold, the call failed for that alone
+		return nil
+	}
+
+	if !filter(tx.TxID, from, to.String()) {
+		return nil
+	}
+
+	e := newEvent(b, tx, info)
+	e.Currency = txevent.TRON_USDT
+	e.Contract = usdt.String()
+	e.Sender = from
+	e.Receiver = to.String()
+	e.Amount = amtInt
+	e.Status = status
+	return r.emit(ctx, e)
+}
+
+func (r *Watcher) doApproval(ctx context.Context, b *Block, info TxInfo, i int, l TxLog, filter func(hash, sender, receiver string) bool) error {
+	owner, err := decodeTopicAddr(l.Topics[1])
+	if err != nil {
+		return fmt.Errorf("decoding owner of %s: %w", info.ID, err)
+	}
+	spender, err := decodeTopicAddr(l.Topics[2])
+	if err != nil {
+		return fmt.Errorf("decoding spender of %s: %w", info.ID, err)
+	}
+
+	if !filter(info.ID, owner, spender) {
+		return nil
+	}
+
+	data, err := hex.DecodeString(l.Data)
+	if err != nil {
+		return fmt.Errorf("decoding approval data of %s: %w", info.ID, err)
+	}
+	args, err := abi.DecodeMap(trc20ApprovalEvent.Inputs[2:], data)
+	if err != nil {
+		return fmt.Errorf("decoding approval of %s: %w", info.ID, err)
+	}
+	args["owner"] = tronaddr.MustParse(owner)
+	args["spender"] = tronaddr.MustParse(spender)
+
+	return r.sendLog(ctx, txevent.Log{
+		Block:    b.BlockHeader.RawData.Number,
+		Hash:     info.ID,
+		LogIndex: i,
+		Contract: usdtContractAddr(r.trongrid.Network()),
+		Event:    trc20ApprovalEvent.Name,
+		Args:     args,
+	})
+}
+
+func (r *Watcher) doLogs(ctx context.Context, b *Block, info TxInfo) error {
+	for i, l := range info.Log {
+		events, ok := r.events[l.Address]
+		if !ok || len(l.Topics) == 0 {
+			continue
+		}
+		event, ok := events[l.Topics[0]]
+		if !ok {
+			continue
+		}
+		if r.approvals && l.Address == encodedUSDTContractAddr(r.trongrid.Network()) && l.Topics[0] == encodedApprovalEvent && len(l.Topics) == 3 {
+			// already went through doApproval
+			continue
+		}
+
+		topics := make([][]byte, len(l.Topics))
+		for j, t := range l.Topics {
+			topic, err := hex.DecodeString(t)
+			if err != nil {
+				return fmt.Errorf("decoding topic of %s: %w", info.ID, err)
+			}
+			topics[j] = topic
+		}
+		data, err := hex.DecodeString(l.Data)
+		if err != nil {
+			return fmt.Errorf("decoding log data of %s: %w", info.ID, err)
+		}
+
+		args, err := event.DecodeLog(topics, data)
+		if err != nil {
+			// a different event can share the same signature, e.g. erc721
+			// transfers index the value
+			continue
+		}
+
+		contract, err := tronaddr.ParseEVM(l.Address)
+		if err != nil {
+			return fmt.Errorf("decoding log address of %s: %w", info.ID, err)
+		}
+
+		err = r.sendLog(ctx, txevent.Log{
+			Block:    b.BlockHeader.RawData.Number,
+			Hash:     info.ID,
+			LogIndex: i,
+			Contract: contract.String(),
+			Event:    event.Name,
+			Args:     args,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendLog gives up once ctx is done, LogCh may not be consumed anymore.
+func (r *Watcher) sendLog(ctx context.Context, l txevent.Log) error {
+	select {
+	case r.LogCh <- l:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newEvent fills in everything that is the same for all events of a tx.
+func newEvent(b *Block, tx Tx, info TxInfo) txevent.E {
+	e := txevent.E{
+		Block:      b.BlockHeader.RawData.Number,
+		BlockHash:  b.BlockID,
+		Hash:       tx.TxID,
+		Fee:        info.Fee,
+		EnergyFee:  info.Receipt.EnergyFee,
+		NetFee:     info.Receipt.NetFee,
+		EnergyUsed: info.Receipt.EnergyUsageTotal,
+	}
+
+	timestamp := info.BlockTimeStamp
+	if timestamp == 0 {
+		timestamp = b.BlockHeader.RawData.Timestamp
+	}
+	if timestamp != 0 {
+		e.Timestamp = time.UnixMilli(timestamp).UTC()
+	}
+
+	if memo, err := hex.DecodeString(tx.RawData.Data); err == nil {
+		e.Memo = string(memo)
+	}
+
+	return e
+}
+
+// txStatus prefers the receipt of contract calls, plain transfers only have a
+// result in the transaction itself.
+func txStatus(tx Tx, info TxInfo) txevent.Status {
+	if info.Receipt.Result != "" {
+		return txevent.Status(info.Receipt.Result)
+	}
+	if len(tx.Ret) > 0 && tx.Ret[0].ContractRet != "" {
+		return txevent.Status(tx.Ret[0].ContractRet)
+	}
+	return txevent.StatusSuccess
+}
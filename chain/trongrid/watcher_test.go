@@ -2,10 +2,17 @@ package trongrid
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/joshuayildiz/wallet/abi"
 	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/joshuayildiz/wallet/txevent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,3 +45,309 @@ func (r *memCursor) Adv() error {
 	r.curr++
 	return nil
 }
+
+const (
+	alice = "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC"
+	bob   = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	carol = "TDvSsdrNM5eeXNL3czpa6AxLDHZA9nwe9K"
+)
+
+func TestWatcherEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	approval := abi.MustParseEvent("Approval(address indexed owner, address indexed spender, uint256 value)")
+	approvalID := approval.ID()
+
+	memoTx := contractTx("t2", alice, usdt, "")
+	memoTx.RawData.Data = hex.EncodeToString([]byte("memo"))
+	memoInfo := txInfo("t2", 10, "SUCCESS",
+		transferLog(usdt, alice, bob, 7),
+		TxLog{
+			Address: hex.EncodeToString(tronaddr.MustParse(usdt).EVMBytes()),
+			Data:    strings.Repeat("f", 64),
+			Topics:  []string{hex.EncodeToString(approvalID[:]), addrTopic(alice), addrTopic(bob)},
+		},
+	)
+	memoInfo.BlockTimeStamp = 1700000000000
+
+	node.addBlock(100, []Tx{
+		transferTx("t1", alice, bob, 5),
+		memoTx,
+	}, []TxInfo{
+		txInfo("t1", 0, ""),
+		memoInfo,
+	})
+	node.addBlock(101, nil, nil)
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithEvents(usdt, approval))
+
+	e := <-watcher.EventCh
+	assert.Equal(t, txevent.TRX, e.Currency)
+	assert.Equal(t, 5, e.Amount)
+	assert.Equal(t, alice, e.Sender)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, txevent.TRON_USDT, e.Currency)
+	assert.Equal(t, 7, e.Amount)
+	assert.Equal(t, bob, e.Receiver)
+	assert.Equal(t, usdt, e.Contract)
+	assert.Equal(t, 0, e.LogIndex)
+	assert.Equal(t, "t2:0", e.ID())
+	assert.Equal(t, blockID(100), e.BlockHash)
+	assert.Equal(t, "memo", e.Memo)
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), e.Timestamp)
+
+	l := <-watcher.LogCh
+	assert.Equal(t, "Approval", l.Event)
+	assert.Equal(t, 1, l.LogIndex)
+	assert.Equal(t, usdt, l.Contract)
+	assert.Equal(t, tronaddr.MustParse(alice), l.Args["owner"])
+	assert.Equal(t, tronaddr.MustParse(bob), l.Args["spender"])
+	assert.Equal(t, 256, l.Args["value"].(*big.Int).BitLen())
+}
+
+func TestWatcherApprovals(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	approvalID := trc20ApprovalEvent.ID()
+	assert.Equal(t, encodedApprovalEvent, hex.EncodeToString(approvalID[:]))
+
+	approvalLog := func(owner string) TxLog {
+		return TxLog{
+			Address: hex.EncodeToString(tronaddr.MustParse(usdt).EVMBytes()),
+			Data:    strings.Repeat("0", 63) + "9",
+			Topics:  []string{encodedApprovalEvent, addrTopic(owner), addrTopic(bob)},
+		}
+	}
+	node.addBlock(100, []Tx{
+		contractTx("t1", alice, usdt, ""),
+	}, []TxInfo{
+		txInfo("t1", 10, "SUCCESS", approvalLog(bob), approvalLog(alice)),
+	})
+	node.addBlock(101, nil, nil)
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return sender == alice
+	}, WithInterval(10*time.Millisecond), WithApprovals())
+
+	l := <-watcher.LogCh
+	assert.Equal(t, "Approval", l.Event)
+	assert.Equal(t, 1, l.LogIndex)
+	assert.Equal(t, tronaddr.MustParse(alice), l.Args["owner"])
+	assert.Equal(t, big.NewInt(9), l.Args["value"])
+
+	// registering the same event does not deliver approvals twice
+	node.addBlock(102, []Tx{
+		contractTx("t2", alice, usdt, ""),
+	}, []TxInfo{
+		txInfo("t2", 10, "SUCCESS", approvalLog(alice)),
+	})
+	node.addBlock(103, nil, nil)
+
+	cursor = &memCursor{curr: 102}
+	watcher = Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return sender == alice
+	}, WithInterval(10*time.Millisecond), WithApprovals(), WithEvents(usdt, trc20ApprovalEvent))
+
+	l = <-watcher.LogCh
+	assert.Equal(t, "t2", l.Hash)
+	select {
+	case l = <-watcher.LogCh:
+		t.Fatalf("approval delivered twice: %+v", l)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatcherUnreadLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	node.addBlock(100, []Tx{
+		contractTx("t1", alice, usdt, ""),
+	}, []TxInfo{
+		txInfo("t1", 10, "SUCCESS", TxLog{
+			Address: hex.EncodeToString(tronaddr.MustParse(usdt).EVMBytes()),
+			Data:    strings.Repeat("0", 64),
+			Topics:  []string{encodedApprovalEvent, addrTopic(alice), addrTopic(bob)},
+		}),
+	})
+	node.addBlock(101, nil, nil)
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithApprovals())
+
+	// nobody reads LogCh, the watcher must still stop
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-watcher.EventCh:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop")
+	}
+	assert.Equal(t, uint(100), cursor.Curr())
+}
+
+func TestWatcherFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	usdt := usdtContractAddr(chain.Mainnet)
+
+	data, err := trc20Transfer.Pack(bob, 3)
+	assert.NoError(t, err)
+
+	// amounts beyond an int must not be truncated into plausible ones
+	huge := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(3))
+	hugeData, err := trc20Transfer.Pack(bob, huge)
+	assert.NoError(t, err)
+	hugeLog := transferLog(usdt, alice, bob, 3)
+	hugeLog.Data = "1" + hugeLog.Data[1:]
+
+	node.addBlock(100, []Tx{
+		contractTx("t0", alice, usdt, hex.EncodeToString(hugeData)),
+		contractTx("t1", alice, usdt, hex.EncodeToString(data)),
+		contractTx("t2", alice, usdt, ""),
+	}, []TxInfo{
+		txInfo("t0", 345, "REVERT"),
+		txInfo("t1", 345, "OUT_OF_ENERGY"),
+		txInfo("t2", 10, "SUCCESS", hugeLog, transferLog(usdt, alice, bob, 7)),
+	})
+	node.addBlock(101, nil, nil)
+
+	cursor := &memCursor{curr: 100}
+	watcher := Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithFailed())
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t1", e.Hash)
+	assert.Equal(t, txevent.StatusOutOfEnergy, e.Status)
+	assert.Equal(t, alice, e.Sender)
+	assert.Equal(t, bob, e.Receiver)
+	assert.Equal(t, 3, e.Amount)
+	assert.Equal(t, 345, e.Fee)
+
+	e = <-watcher.EventCh
+	assert.Equal(t, "t2", e.Hash)
+	assert.Equal(t, txevent.StatusSuccess, e.Status)
+	assert.Equal(t, 7, e.Amount)
+	assert.Equal(t, 1, e.LogIndex)
+}
+
+func TestWatcherHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, []Tx{transferTx("t1", alice, bob, 5)}, []TxInfo{txInfo("t1", 0, "SUCCESS")})
+	node.addBlock(101, []Tx{transferTx("t2", bob, alice, 6)}, []TxInfo{txInfo("t2", 0, "SUCCESS")})
+	node.addBlock(102, nil, nil)
+
+	handled := make(chan string, 8)
+	calls := 0
+	handler := func(ctx context.Context, e txevent.E) error {
+		calls++
+		handled <- e.Hash
+		if calls == 1 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+
+	cursor := &memCursor{curr: 100}
+	Watch(ctx, client, cursor, func(hash, sender, receiver string) bool {
+		return true
+	}, WithInterval(10*time.Millisecond), WithHandler(handler))
+
+	// the failed block is retried before moving on
+	assert.Equal(t, "t1", <-handled)
+	assert.Equal(t, "t1", <-handled)
+	assert.Equal(t, "t2", <-handled)
+}
+
+func TestWatchAddrs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node, client := newFakeNode(t)
+	node.addBlock(100, []Tx{
+		transferTx("t1", alice, bob, 1),
+		transferTx("t2", bob, alice, 2),
+		transferTx("t3", alice, alice, 3),
+		transferTx("t4", bob, carol, 4),
+	}, []TxInfo{
+		txInfo("t1", 0, "SUCCESS"),
+		txInfo("t2", 0, "SUCCESS"),
+		txInfo("t3", 0, "SUCCESS"),
+		txInfo("t4", 0, "SUCCESS"),
+	})
+	node.addBlock(101, []Tx{transferTx("t5", bob, carol, 5)}, []TxInfo{txInfo("t5", 0, "SUCCESS")})
+	node.addBlock(102, nil, nil)
+
+	set, err := NewAddrSet(alice)
+	assert.NoError(t, err)
+
+	cursor := &memCursor{curr: 100}
+	watcher := WatchAddrs(ctx, client, cursor, set, WithInterval(10*time.Millisecond))
+
+	for _, want := range []struct {
+		hash      string
+		direction txevent.Direction
+	}{
+		{"t1", txevent.DirectionOut},
+		{"t2", txevent.DirectionIn},
+		{"t3", txevent.DirectionSelf},
+	} {
+		e := <-watcher.EventCh
+		assert.Equal(t, want.hash, e.Hash)
+		assert.Equal(t, want.direction, e.Direction)
+	}
+
+	assert.Eventually(t, func() bool {
+		return watcher.curr.Load() == 102
+	}, time.Second, 5*time.Millisecond)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watcher.Backfill(ctx, 100, carol)
+	}()
+
+	e := <-watcher.EventCh
+	assert.Equal(t, "t4", e.Hash)
+	assert.Equal(t, txevent.DirectionIn, e.Direction)
+	e = <-watcher.EventCh
+	assert.Equal(t, "t5", e.Hash)
+	assert.NoError(t, <-errCh)
+	assert.True(t, set.Contains(carol))
+}
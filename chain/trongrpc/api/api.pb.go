@@ -0,0 +1,655 @@
+// Subset of api/api.proto of github.com/tronprotocol/protocol without the
+// http annotations, messages and methods keep their official names and
+// numbers.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: api/api.proto
+
+package api
+
+import (
+	core "github.com/joshuayildiz/wallet/chain/trongrpc/core"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReturnResponseCode int32
+
+const (
+	Return_SUCCESS                         ReturnResponseCode = 0
+	Return_SIGERROR                        ReturnResponseCode = 1
+	Return_CONTRACT_VALIDATE_ERROR         ReturnResponseCode = 2
+	Return_CONTRACT_EXE_ERROR              ReturnResponseCode = 3
+	Return_BANDWITH_ERROR                  ReturnResponseCode = 4
+	Return_DUP_TRANSACTION_ERROR           ReturnResponseCode = 5
+	Return_TAPOS_ERROR                     ReturnResponseCode = 6
+	Return_TOO_BIG_TRANSACTION_ERROR       ReturnResponseCode = 7
+	Return_TRANSACTION_EXPIRATION_ERROR    ReturnResponseCode = 8
+	Return_SERVER_BUSY                     ReturnResponseCode = 9
+	Return_NO_CONNECTION                   ReturnResponseCode = 10
+	Return_NOT_ENOUGH_EFFECTIVE_CONNECTION ReturnResponseCode = 11
+	Return_BLOCK_UNSOLIDIFIED              ReturnResponseCode = 12
+	Return_OTHER_ERROR                     ReturnResponseCode = 20
+)
+
+// Enum value maps for ReturnResponseCode.
+var (
+	ReturnResponseCode_name = map[int32]string{
+		0:  "SUCCESS",
+		1:  "SIGERROR",
+		2:  "CONTRACT_VALIDATE_ERROR",
+		3:  "CONTRACT_EXE_ERROR",
+		4:  "BANDWITH_ERROR",
+		5:  "DUP_TRANSACTION_ERROR",
+		6:  "TAPOS_ERROR",
+		7:  "TOO_BIG_TRANSACTION_ERROR",
+		8:  "TRANSACTION_EXPIRATION_ERROR",
+		9:  "SERVER_BUSY",
+		10: "NO_CONNECTION",
+		11: "NOT_ENOUGH_EFFECTIVE_CONNECTION",
+		12: "BLOCK_UNSOLIDIFIED",
+		20: "OTHER_ERROR",
+	}
+	ReturnResponseCode_value = map[string]int32{
+		"SUCCESS":                         0,
+		"SIGERROR":                        1,
+		"CONTRACT_VALIDATE_ERROR":         2,
+		"CONTRACT_EXE_ERROR":              3,
+		"BANDWITH_ERROR":                  4,
+		"DUP_TRANSACTION_ERROR":           5,
+		"TAPOS_ERROR":                     6,
+		"TOO_BIG_TRANSACTION_ERROR":       7,
+		"TRANSACTION_EXPIRATION_ERROR":    8,
+		"SERVER_BUSY":                     9,
+		"NO_CONNECTION":                   10,
+		"NOT_ENOUGH_EFFECTIVE_CONNECTION": 11,
+		"BLOCK_UNSOLIDIFIED":              12,
+		"OTHER_ERROR":                     20,
+	}
+)
+
+func (x ReturnResponseCode) Enum() *ReturnResponseCode {
+	p := new(ReturnResponseCode)
+	*p = x
+	return p
+}
+
+func (x ReturnResponseCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReturnResponseCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_api_proto_enumTypes[0].Descriptor()
+}
+
+func (ReturnResponseCode) Type() protoreflect.EnumType {
+	return &file_api_api_proto_enumTypes[0]
+}
+
+func (x ReturnResponseCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReturnResponseCode.Descriptor instead.
+func (ReturnResponseCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type Return struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Code          ReturnResponseCode     `protobuf:"varint,2,opt,name=code,proto3,enum=protocol.ReturnResponseCode" json:"code,omitempty"`
+	Message       []byte                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_api_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Return) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+func (x *Return) GetCode() ReturnResponseCode {
+	if x != nil {
+		return x.Code
+	}
+	return Return_SUCCESS
+}
+
+func (x *Return) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type NumberMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Num           int64                  `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NumberMessage) Reset() {
+	*x = NumberMessage{}
+	mi := &file_api_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NumberMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumberMessage) ProtoMessage() {}
+
+func (x *NumberMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumberMessage.ProtoReflect.Descriptor instead.
+func (*NumberMessage) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NumberMessage) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+type BytesMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BytesMessage) Reset() {
+	*x = BytesMessage{}
+	mi := &file_api_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BytesMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BytesMessage) ProtoMessage() {}
+
+func (x *BytesMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BytesMessage.ProtoReflect.Descriptor instead.
+func (*BytesMessage) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BytesMessage) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type EmptyMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyMessage) Reset() {
+	*x = EmptyMessage{}
+	mi := &file_api_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyMessage) ProtoMessage() {}
+
+func (x *EmptyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyMessage.ProtoReflect.Descriptor instead.
+func (*EmptyMessage) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{3}
+}
+
+type TransactionExtention struct {
+	state          protoimpl.MessageState      `protogen:"open.v1"`
+	Transaction    *core.Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Txid           []byte                      `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	ConstantResult [][]byte                    `protobuf:"bytes,3,rep,name=constant_result,json=constantResult,proto3" json:"constant_result,omitempty"`
+	Result         *Return                     `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	EnergyUsed     int64                       `protobuf:"varint,5,opt,name=energy_used,json=energyUsed,proto3" json:"energy_used,omitempty"`
+	Logs           []*core.TransactionInfo_Log `protobuf:"bytes,6,rep,name=logs,proto3" json:"logs,omitempty"`
+	EnergyPenalty  int64                       `protobuf:"varint,8,opt,name=energy_penalty,json=energyPenalty,proto3" json:"energy_penalty,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransactionExtention) Reset() {
+	*x = TransactionExtention{}
+	mi := &file_api_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionExtention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionExtention) ProtoMessage() {}
+
+func (x *TransactionExtention) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionExtention.ProtoReflect.Descriptor instead.
+func (*TransactionExtention) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransactionExtention) GetTransaction() *core.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *TransactionExtention) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *TransactionExtention) GetConstantResult() [][]byte {
+	if x != nil {
+		return x.ConstantResult
+	}
+	return nil
+}
+
+func (x *TransactionExtention) GetResult() *Return {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TransactionExtention) GetEnergyUsed() int64 {
+	if x != nil {
+		return x.EnergyUsed
+	}
+	return 0
+}
+
+func (x *TransactionExtention) GetLogs() []*core.TransactionInfo_Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *TransactionExtention) GetEnergyPenalty() int64 {
+	if x != nil {
+		return x.EnergyPenalty
+	}
+	return 0
+}
+
+type BlockExtention struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Transactions  []*TransactionExtention `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	BlockHeader   *core.BlockHeader       `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	Blockid       []byte                  `protobuf:"bytes,3,opt,name=blockid,proto3" json:"blockid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockExtention) Reset() {
+	*x = BlockExtention{}
+	mi := &file_api_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockExtention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockExtention) ProtoMessage() {}
+
+func (x *BlockExtention) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockExtention.ProtoReflect.Descriptor instead.
+func (*BlockExtention) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlockExtention) GetTransactions() []*TransactionExtention {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *BlockExtention) GetBlockHeader() *core.BlockHeader {
+	if x != nil {
+		return x.BlockHeader
+	}
+	return nil
+}
+
+func (x *BlockExtention) GetBlockid() []byte {
+	if x != nil {
+		return x.Blockid
+	}
+	return nil
+}
+
+type TransactionInfoList struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	TransactionInfo []*core.TransactionInfo `protobuf:"bytes,1,rep,name=transactionInfo,proto3" json:"transactionInfo,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransactionInfoList) Reset() {
+	*x = TransactionInfoList{}
+	mi := &file_api_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionInfoList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionInfoList) ProtoMessage() {}
+
+func (x *TransactionInfoList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionInfoList.ProtoReflect.Descriptor instead.
+func (*TransactionInfoList) Descriptor() ([]byte, []int) {
+	return file_api_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TransactionInfoList) GetTransactionInfo() []*core.TransactionInfo {
+	if x != nil {
+		return x.TransactionInfo
+	}
+	return nil
+}
+
+var File_api_api_proto protoreflect.FileDescriptor
+
+const file_api_api_proto_rawDesc = "" +
+	"\n" +
+	"\rapi/api.proto\x12\bprotocol\x1a\x0fcore/Tron.proto\x1a$core/contract/balance_contract.proto\x1a\"core/contract/smart_contract.proto\"\xc3\x03\n" +
+	"\x06Return\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\x122\n" +
+	"\x04code\x18\x02 \x01(\x0e2\x1e.protocol.Return.response_codeR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\fR\amessage\"\xd2\x02\n" +
+	"\rresponse_code\x12\v\n" +
+	"\aSUCCESS\x10\x00\x12\f\n" +
+	"\bSIGERROR\x10\x01\x12\x1b\n" +
+	"\x17CONTRACT_VALIDATE_ERROR\x10\x02\x12\x16\n" +
+	"\x12CONTRACT_EXE_ERROR\x10\x03\x12\x12\n" +
+	"\x0eBANDWITH_ERROR\x10\x04\x12\x19\n" +
+	"\x15DUP_TRANSACTION_ERROR\x10\x05\x12\x0f\n" +
+	"\vTAPOS_ERROR\x10\x06\x12\x1d\n" +
+	"\x19TOO_BIG_TRANSACTION_ERROR\x10\a\x12 \n" +
+	"\x1cTRANSACTION_EXPIRATION_ERROR\x10\b\x12\x0f\n" +
+	"\vSERVER_BUSY\x10\t\x12\x11\n" +
+	"\rNO_CONNECTION\x10\n" +
+	"\x12#\n" +
+	"\x1fNOT_ENOUGH_EFFECTIVE_CONNECTION\x10\v\x12\x16\n" +
+	"\x12BLOCK_UNSOLIDIFIED\x10\f\x12\x0f\n" +
+	"\vOTHER_ERROR\x10\x14\"!\n" +
+	"\rNumberMessage\x12\x10\n" +
+	"\x03num\x18\x01 \x01(\x03R\x03num\"$\n" +
+	"\fBytesMessage\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"\x0e\n" +
+	"\fEmptyMessage\"\xb1\x02\n" +
+	"\x14TransactionExtention\x127\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x15.protocol.TransactionR\vtransaction\x12\x12\n" +
+	"\x04txid\x18\x02 \x01(\fR\x04txid\x12'\n" +
+	"\x0fconstant_result\x18\x03 \x03(\fR\x0econstantResult\x12(\n" +
+	"\x06result\x18\x04 \x01(\v2\x10.protocol.ReturnR\x06result\x12\x1f\n" +
+	"\venergy_used\x18\x05 \x01(\x03R\n" +
+	"energyUsed\x121\n" +
+	"\x04logs\x18\x06 \x03(\v2\x1d.protocol.TransactionInfo.LogR\x04logs\x12%\n" +
+	"\x0eenergy_penalty\x18\b \x01(\x03R\renergyPenalty\"\xa8\x01\n" +
+	"\x0eBlockExtention\x12B\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1e.protocol.TransactionExtentionR\ftransactions\x128\n" +
+	"\fblock_header\x18\x02 \x01(\v2\x15.protocol.BlockHeaderR\vblockHeader\x12\x18\n" +
+	"\ablockid\x18\x03 \x01(\fR\ablockid\"Z\n" +
+	"\x13TransactionInfoList\x12C\n" +
+	"\x0ftransactionInfo\x18\x01 \x03(\v2\x19.protocol.TransactionInfoR\x0ftransactionInfo2\xbb\x05\n" +
+	"\x06Wallet\x124\n" +
+	"\n" +
+	"GetAccount\x12\x11.protocol.Account\x1a\x11.protocol.Account\"\x00\x12R\n" +
+	"\x12CreateTransaction2\x12\x1a.protocol.TransferContract\x1a\x1e.protocol.TransactionExtention\"\x00\x12A\n" +
+	"\x14BroadcastTransaction\x12\x15.protocol.Transaction\x1a\x10.protocol.Return\"\x00\x12B\n" +
+	"\fGetNowBlock2\x12\x16.protocol.EmptyMessage\x1a\x18.protocol.BlockExtention\"\x00\x12E\n" +
+	"\x0eGetBlockByNum2\x12\x17.protocol.NumberMessage\x1a\x18.protocol.BlockExtention\"\x00\x12S\n" +
+	"\x0fTriggerContract\x12\x1e.protocol.TriggerSmartContract\x1a\x1e.protocol.TransactionExtention\"\x00\x12[\n" +
+	"\x17TriggerConstantContract\x12\x1e.protocol.TriggerSmartContract\x1a\x1e.protocol.TransactionExtention\"\x00\x12M\n" +
+	"\x16GetTransactionInfoById\x12\x16.protocol.BytesMessage\x1a\x19.protocol.TransactionInfo\"\x00\x12X\n" +
+	"\x1cGetTransactionInfoByBlockNum\x12\x17.protocol.NumberMessage\x1a\x1d.protocol.TransactionInfoList\"\x002\xd7\x03\n" +
+	"\x0eWalletSolidity\x124\n" +
+	"\n" +
+	"GetAccount\x12\x11.protocol.Account\x1a\x11.protocol.Account\"\x00\x12B\n" +
+	"\fGetNowBlock2\x12\x16.protocol.EmptyMessage\x1a\x18.protocol.BlockExtention\"\x00\x12E\n" +
+	"\x0eGetBlockByNum2\x12\x17.protocol.NumberMessage\x1a\x18.protocol.BlockExtention\"\x00\x12M\n" +
+	"\x16GetTransactionInfoById\x12\x16.protocol.BytesMessage\x1a\x19.protocol.TransactionInfo\"\x00\x12[\n" +
+	"\x17TriggerConstantContract\x12\x1e.protocol.TriggerSmartContract\x1a\x1e.protocol.TransactionExtention\"\x00\x12X\n" +
+	"\x1cGetTransactionInfoByBlockNum\x12\x17.protocol.NumberMessage\x1a\x1d.protocol.TransactionInfoList\"\x00B3Z1github.com/joshuayildiz/wallet/chain/trongrpc/apib\x06proto3"
+
+var (
+	file_api_api_proto_rawDescOnce sync.Once
+	file_api_api_proto_rawDescData []byte
+)
+
+func file_api_api_proto_rawDescGZIP() []byte {
+	file_api_api_proto_rawDescOnce.Do(func() {
+		file_api_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_api_proto_rawDesc), len(file_api_api_proto_rawDesc)))
+	})
+	return file_api_api_proto_rawDescData
+}
+
+var file_api_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_api_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_api_proto_goTypes = []any{
+	(ReturnResponseCode)(0),           // 0: protocol.Return.response_code
+	(*Return)(nil),                    // 1: protocol.Return
+	(*NumberMessage)(nil),             // 2: protocol.NumberMessage
+	(*BytesMessage)(nil),              // 3: protocol.BytesMessage
+	(*EmptyMessage)(nil),              // 4: protocol.EmptyMessage
+	(*TransactionExtention)(nil),      // 5: protocol.TransactionExtention
+	(*BlockExtention)(nil),            // 6: protocol.BlockExtention
+	(*TransactionInfoList)(nil),       // 7: protocol.TransactionInfoList
+	(*core.Transaction)(nil),          // 8: protocol.Transaction
+	(*core.TransactionInfo_Log)(nil),  // 9: protocol.TransactionInfo.Log
+	(*core.BlockHeader)(nil),          // 10: protocol.BlockHeader
+	(*core.TransactionInfo)(nil),      // 11: protocol.TransactionInfo
+	(*core.Account)(nil),              // 12: protocol.Account
+	(*core.TransferContract)(nil),     // 13: protocol.TransferContract
+	(*core.TriggerSmartContract)(nil), // 14: protocol.TriggerSmartContract
+}
+var file_api_api_proto_depIdxs = []int32{
+	0,  // 0: protocol.Return.code:type_name -> protocol.Return.response_code
+	8,  // 1: protocol.TransactionExtention.transaction:type_name -> protocol.Transaction
+	1,  // 2: protocol.TransactionExtention.result:type_name -> protocol.Return
+	9,  // 3: protocol.TransactionExtention.logs:type_name -> protocol.TransactionInfo.Log
+	5,  // 4: protocol.BlockExtention.transactions:type_name -> protocol.TransactionExtention
+	10, // 5: protocol.BlockExtention.block_header:type_name -> protocol.BlockHeader
+	11, // 6: protocol.TransactionInfoList.transactionInfo:type_name -> protocol.TransactionInfo
+	12, // 7: protocol.Wallet.GetAccount:input_type -> protocol.Account
+	13, // 8: protocol.Wallet.CreateTransaction2:input_type -> protocol.TransferContract
+	8,  // 9: protocol.Wallet.BroadcastTransaction:input_type -> protocol.Transaction
+	4,  // 10: protocol.Wallet.GetNowBlock2:input_type -> protocol.EmptyMessage
+	2,  // 11: protocol.Wallet.GetBlockByNum2:input_type -> protocol.NumberMessage
+	14, // 12: protocol.Wallet.TriggerContract:input_type -> protocol.TriggerSmartContract
+	14, // 13: protocol.Wallet.TriggerConstantContract:input_type -> protocol.TriggerSmartContract
+	3,  // 14: protocol.Wallet.GetTransactionInfoById:input_type -> protocol.BytesMessage
+	2,  // 15: protocol.Wallet.GetTransactionInfoByBlockNum:input_type -> protocol.NumberMessage
+	12, // 16: protocol.WalletSolidity.GetAccount:input_type -> protocol.Account
+	4,  // 17: protocol.WalletSolidity.GetNowBlock2:input_type -> protocol.EmptyMessage
+	2,  // 18: protocol.WalletSolidity.GetBlockByNum2:input_type -> protocol.NumberMessage
+	3,  // 19: protocol.WalletSolidity.GetTransactionInfoById:input_type -> protocol.BytesMessage
+	14, // 20: protocol.WalletSolidity.TriggerConstantContract:input_type -> protocol.TriggerSmartContract
+	2,  // 21: protocol.WalletSolidity.GetTransactionInfoByBlockNum:input_type -> protocol.NumberMessage
+	12, // 22: protocol.Wallet.GetAccount:output_type -> protocol.Account
+	5,  // 23: protocol.Wallet.CreateTransaction2:output_type -> protocol.TransactionExtention
+	1,  // 24: protocol.Wallet.BroadcastTransaction:output_type -> protocol.Return
+	6,  // 25: protocol.Wallet.GetNowBlock2:output_type -> protocol.BlockExtention
+	6,  // 26: protocol.Wallet.GetBlockByNum2:output_type -> protocol.BlockExtention
+	5,  // 27: protocol.Wallet.TriggerContract:output_type -> protocol.TransactionExtention
+	5,  // 28: protocol.Wallet.TriggerConstantContract:output_type -> protocol.TransactionExtention
+	11, // 29: protocol.Wallet.GetTransactionInfoById:output_type -> protocol.TransactionInfo
+	7,  // 30: protocol.Wallet.GetTransactionInfoByBlockNum:output_type -> protocol.TransactionInfoList
+	12, // 31: protocol.WalletSolidity.GetAccount:output_type -> protocol.Account
+	6,  // 32: protocol.WalletSolidity.GetNowBlock2:output_type -> protocol.BlockExtention
+	6,  // 33: protocol.WalletSolidity.GetBlockByNum2:output_type -> protocol.BlockExtention
+	11, // 34: protocol.WalletSolidity.GetTransactionInfoById:output_type -> protocol.TransactionInfo
+	5,  // 35: protocol.WalletSolidity.TriggerConstantContract:output_type -> protocol.TransactionExtention
+	7,  // 36: protocol.WalletSolidity.GetTransactionInfoByBlockNum:output_type -> protocol.TransactionInfoList
+	22, // [22:37] is the sub-list for method output_type
+	7,  // [7:22] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_api_api_proto_init() }
+func file_api_api_proto_init() {
+	if File_api_api_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_api_proto_rawDesc), len(file_api_api_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_api_api_proto_goTypes,
+		DependencyIndexes: file_api_api_proto_depIdxs,
+		EnumInfos:         file_api_api_proto_enumTypes,
+		MessageInfos:      file_api_api_proto_msgTypes,
+	}.Build()
+	File_api_api_proto = out.File
+	file_api_api_proto_goTypes = nil
+	file_api_api_proto_depIdxs = nil
+}
@@ -0,0 +1,722 @@
+// Subset of api/api.proto of github.com/tronprotocol/protocol without the
+// http annotations, messages and methods keep their official names and
+// numbers.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: api/api.proto
+
+package api
+
+import (
+	context "context"
+	core "github.com/joshuayildiz/wallet/chain/trongrpc/core"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Wallet_GetAccount_FullMethodName                   = "/protocol.Wallet/GetAccount"
+	Wallet_CreateTransaction2_FullMethodName           = "/protocol.Wallet/CreateTransaction2"
+	Wallet_BroadcastTransaction_FullMethodName         = "/protocol.Wallet/BroadcastTransaction"
+	Wallet_GetNowBlock2_FullMethodName                 = "/protocol.Wallet/GetNowBlock2"
+	Wallet_GetBlockByNum2_FullMethodName               = "/protocol.Wallet/GetBlockByNum2"
+	Wallet_TriggerContract_FullMethodName              = "/protocol.Wallet/TriggerContract"
+	Wallet_TriggerConstantContract_FullMethodName      = "/protocol.Wallet/TriggerConstantContract"
+	Wallet_GetTransactionInfoById_FullMethodName       = "/protocol.Wallet/GetTransactionInfoById"
+	Wallet_GetTransactionInfoByBlockNum_FullMethodName = "/protocol.Wallet/GetTransactionInfoByBlockNum"
+)
+
+// WalletClient is the client API for Wallet service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WalletClient interface {
+	GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error)
+	CreateTransaction2(ctx context.Context, in *core.TransferContract, opts ...grpc.CallOption) (*TransactionExtention, error)
+	BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*Return, error)
+	GetNowBlock2(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*BlockExtention, error)
+	GetBlockByNum2(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*BlockExtention, error)
+	TriggerContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*TransactionExtention, error)
+	TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*TransactionExtention, error)
+	GetTransactionInfoById(ctx context.Context, in *BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error)
+	GetTransactionInfoByBlockNum(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*TransactionInfoList, error)
+}
+
+type walletClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletClient(cc grpc.ClientConnInterface) WalletClient {
+	return &walletClient{cc}
+}
+
+func (c *walletClient) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.Account)
+	err := c.cc.Invoke(ctx, Wallet_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) CreateTransaction2(ctx context.Context, in *core.TransferContract, opts ...grpc.CallOption) (*TransactionExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionExtention)
+	err := c.cc.Invoke(ctx, Wallet_CreateTransaction2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*Return, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Return)
+	err := c.cc.Invoke(ctx, Wallet_BroadcastTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetNowBlock2(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*BlockExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockExtention)
+	err := c.cc.Invoke(ctx, Wallet_GetNowBlock2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetBlockByNum2(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*BlockExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockExtention)
+	err := c.cc.Invoke(ctx, Wallet_GetBlockByNum2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) TriggerContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*TransactionExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionExtention)
+	err := c.cc.Invoke(ctx, Wallet_TriggerContract_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*TransactionExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionExtention)
+	err := c.cc.Invoke(ctx, Wallet_TriggerConstantContract_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetTransactionInfoById(ctx context.Context, in *BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.TransactionInfo)
+	err := c.cc.Invoke(ctx, Wallet_GetTransactionInfoById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetTransactionInfoByBlockNum(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*TransactionInfoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionInfoList)
+	err := c.cc.Invoke(ctx, Wallet_GetTransactionInfoByBlockNum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServer is the server API for Wallet service.
+// All implementations must embed UnimplementedWalletServer
+// for forward compatibility.
+type WalletServer interface {
+	GetAccount(context.Context, *core.Account) (*core.Account, error)
+	CreateTransaction2(context.Context, *core.TransferContract) (*TransactionExtention, error)
+	BroadcastTransaction(context.Context, *core.Transaction) (*Return, error)
+	GetNowBlock2(context.Context, *EmptyMessage) (*BlockExtention, error)
+	GetBlockByNum2(context.Context, *NumberMessage) (*BlockExtention, error)
+	TriggerContract(context.Context, *core.TriggerSmartContract) (*TransactionExtention, error)
+	TriggerConstantContract(context.Context, *core.TriggerSmartContract) (*TransactionExtention, error)
+	GetTransactionInfoById(context.Context, *BytesMessage) (*core.TransactionInfo, error)
+	GetTransactionInfoByBlockNum(context.Context, *NumberMessage) (*TransactionInfoList, error)
+	mustEmbedUnimplementedWalletServer()
+}
+
+// UnimplementedWalletServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWalletServer struct{}
+
+func (UnimplementedWalletServer) GetAccount(context.Context, *core.Account) (*core.Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedWalletServer) CreateTransaction2(context.Context, *core.TransferContract) (*TransactionExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTransaction2 not implemented")
+}
+func (UnimplementedWalletServer) BroadcastTransaction(context.Context, *core.Transaction) (*Return, error) {
+	return nil, status.Error(codes.Unimplemented, "method BroadcastTransaction not implemented")
+}
+func (UnimplementedWalletServer) GetNowBlock2(context.Context, *EmptyMessage) (*BlockExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNowBlock2 not implemented")
+}
+func (UnimplementedWalletServer) GetBlockByNum2(context.Context, *NumberMessage) (*BlockExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockByNum2 not implemented")
+}
+func (UnimplementedWalletServer) TriggerContract(context.Context, *core.TriggerSmartContract) (*TransactionExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriggerContract not implemented")
+}
+func (UnimplementedWalletServer) TriggerConstantContract(context.Context, *core.TriggerSmartContract) (*TransactionExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriggerConstantContract not implemented")
+}
+func (UnimplementedWalletServer) GetTransactionInfoById(context.Context, *BytesMessage) (*core.TransactionInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransactionInfoById not implemented")
+}
+func (UnimplementedWalletServer) GetTransactionInfoByBlockNum(context.Context, *NumberMessage) (*TransactionInfoList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransactionInfoByBlockNum not implemented")
+}
+func (UnimplementedWalletServer) mustEmbedUnimplementedWalletServer() {}
+func (UnimplementedWalletServer) testEmbeddedByValue()                {}
+
+// UnsafeWalletServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServer will
+// result in compilation errors.
+type UnsafeWalletServer interface {
+	mustEmbedUnimplementedWalletServer()
+}
+
+func RegisterWalletServer(s grpc.ServiceRegistrar, srv WalletServer) {
+	// If the following call panics, it indicates UnimplementedWalletServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Wallet_ServiceDesc, srv)
+}
+
+func _Wallet_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.Account)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetAccount(ctx, req.(*core.Account))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_CreateTransaction2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.TransferContract)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).CreateTransaction2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_CreateTransaction2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).CreateTransaction2(ctx, req.(*core.TransferContract))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_BroadcastTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.Transaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).BroadcastTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_BroadcastTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).BroadcastTransaction(ctx, req.(*core.Transaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetNowBlock2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetNowBlock2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetNowBlock2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetNowBlock2(ctx, req.(*EmptyMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetBlockByNum2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NumberMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetBlockByNum2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetBlockByNum2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetBlockByNum2(ctx, req.(*NumberMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_TriggerContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.TriggerSmartContract)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).TriggerContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_TriggerContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).TriggerContract(ctx, req.(*core.TriggerSmartContract))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_TriggerConstantContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.TriggerSmartContract)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).TriggerConstantContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_TriggerConstantContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).TriggerConstantContract(ctx, req.(*core.TriggerSmartContract))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetTransactionInfoById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BytesMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetTransactionInfoById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetTransactionInfoById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetTransactionInfoById(ctx, req.(*BytesMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetTransactionInfoByBlockNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NumberMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetTransactionInfoByBlockNum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetTransactionInfoByBlockNum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetTransactionInfoByBlockNum(ctx, req.(*NumberMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Wallet_ServiceDesc is the grpc.ServiceDesc for Wallet service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Wallet_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.Wallet",
+	HandlerType: (*WalletServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccount",
+			Handler:    _Wallet_GetAccount_Handler,
+		},
+		{
+			MethodName: "CreateTransaction2",
+			Handler:    _Wallet_CreateTransaction2_Handler,
+		},
+		{
+			MethodName: "BroadcastTransaction",
+			Handler:    _Wallet_BroadcastTransaction_Handler,
+		},
+		{
+			MethodName: "GetNowBlock2",
+			Handler:    _Wallet_GetNowBlock2_Handler,
+		},
+		{
+			MethodName: "GetBlockByNum2",
+			Handler:    _Wallet_GetBlockByNum2_Handler,
+		},
+		{
+			MethodName: "TriggerContract",
+			Handler:    _Wallet_TriggerContract_Handler,
+		},
+		{
+			MethodName: "TriggerConstantContract",
+			Handler:    _Wallet_TriggerConstantContract_Handler,
+		},
+		{
+			MethodName: "GetTransactionInfoById",
+			Handler:    _Wallet_GetTransactionInfoById_Handler,
+		},
+		{
+			MethodName: "GetTransactionInfoByBlockNum",
+			Handler:    _Wallet_GetTransactionInfoByBlockNum_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/api.proto",
+}
+
+const (
+	WalletSolidity_GetAccount_FullMethodName                   = "/protocol.WalletSolidity/GetAccount"
+	WalletSolidity_GetNowBlock2_FullMethodName                 = "/protocol.WalletSolidity/GetNowBlock2"
+	WalletSolidity_GetBlockByNum2_FullMethodName               = "/protocol.WalletSolidity/GetBlockByNum2"
+	WalletSolidity_GetTransactionInfoById_FullMethodName       = "/protocol.WalletSolidity/GetTransactionInfoById"
+	WalletSolidity_TriggerConstantContract_FullMethodName      = "/protocol.WalletSolidity/TriggerConstantContract"
+	WalletSolidity_GetTransactionInfoByBlockNum_FullMethodName = "/protocol.WalletSolidity/GetTransactionInfoByBlockNum"
+)
+
+// WalletSolidityClient is the client API for WalletSolidity service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WalletSolidityClient interface {
+	GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error)
+	GetNowBlock2(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*BlockExtention, error)
+	GetBlockByNum2(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*BlockExtention, error)
+	GetTransactionInfoById(ctx context.Context, in *BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error)
+	TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*TransactionExtention, error)
+	GetTransactionInfoByBlockNum(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*TransactionInfoList, error)
+}
+
+type walletSolidityClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletSolidityClient(cc grpc.ClientConnInterface) WalletSolidityClient {
+	return &walletSolidityClient{cc}
+}
+
+func (c *walletSolidityClient) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.Account)
+	err := c.cc.Invoke(ctx, WalletSolidity_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletSolidityClient) GetNowBlock2(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*BlockExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockExtention)
+	err := c.cc.Invoke(ctx, WalletSolidity_GetNowBlock2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletSolidityClient) GetBlockByNum2(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*BlockExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockExtention)
+	err := c.cc.Invoke(ctx, WalletSolidity_GetBlockByNum2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletSolidityClient) GetTransactionInfoById(ctx context.Context, in *BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.TransactionInfo)
+	err := c.cc.Invoke(ctx, WalletSolidity_GetTransactionInfoById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletSolidityClient) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*TransactionExtention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionExtention)
+	err := c.cc.Invoke(ctx, WalletSolidity_TriggerConstantContract_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletSolidityClient) GetTransactionInfoByBlockNum(ctx context.Context, in *NumberMessage, opts ...grpc.CallOption) (*TransactionInfoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionInfoList)
+	err := c.cc.Invoke(ctx, WalletSolidity_GetTransactionInfoByBlockNum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletSolidityServer is the server API for WalletSolidity service.
+// All implementations must embed UnimplementedWalletSolidityServer
+// for forward compatibility.
+type WalletSolidityServer interface {
+	GetAccount(context.Context, *core.Account) (*core.Account, error)
+	GetNowBlock2(context.Context, *EmptyMessage) (*BlockExtention, error)
+	GetBlockByNum2(context.Context, *NumberMessage) (*BlockExtention, error)
+	GetTransactionInfoById(context.Context, *BytesMessage) (*core.TransactionInfo, error)
+	TriggerConstantContract(context.Context, *core.TriggerSmartContract) (*TransactionExtention, error)
+	GetTransactionInfoByBlockNum(context.Context, *NumberMessage) (*TransactionInfoList, error)
+	mustEmbedUnimplementedWalletSolidityServer()
+}
+
+// UnimplementedWalletSolidityServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWalletSolidityServer struct{}
+
+func (UnimplementedWalletSolidityServer) GetAccount(context.Context, *core.Account) (*core.Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedWalletSolidityServer) GetNowBlock2(context.Context, *EmptyMessage) (*BlockExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNowBlock2 not implemented")
+}
+func (UnimplementedWalletSolidityServer) GetBlockByNum2(context.Context, *NumberMessage) (*BlockExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockByNum2 not implemented")
+}
+func (UnimplementedWalletSolidityServer) GetTransactionInfoById(context.Context, *BytesMessage) (*core.TransactionInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransactionInfoById not implemented")
+}
+func (UnimplementedWalletSolidityServer) TriggerConstantContract(context.Context, *core.TriggerSmartContract) (*TransactionExtention, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriggerConstantContract not implemented")
+}
+func (UnimplementedWalletSolidityServer) GetTransactionInfoByBlockNum(context.Context, *NumberMessage) (*TransactionInfoList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransactionInfoByBlockNum not implemented")
+}
+func (UnimplementedWalletSolidityServer) mustEmbedUnimplementedWalletSolidityServer() {}
+func (UnimplementedWalletSolidityServer) testEmbeddedByValue()                        {}
+
+// UnsafeWalletSolidityServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletSolidityServer will
+// result in compilation errors.
+type UnsafeWalletSolidityServer interface {
+	mustEmbedUnimplementedWalletSolidityServer()
+}
+
+func RegisterWalletSolidityServer(s grpc.ServiceRegistrar, srv WalletSolidityServer) {
+	// If the following call panics, it indicates UnimplementedWalletSolidityServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WalletSolidity_ServiceDesc, srv)
+}
+
+func _WalletSolidity_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.Account)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletSolidityServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletSolidity_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletSolidityServer).GetAccount(ctx, req.(*core.Account))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletSolidity_GetNowBlock2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletSolidityServer).GetNowBlock2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletSolidity_GetNowBlock2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletSolidityServer).GetNowBlock2(ctx, req.(*EmptyMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletSolidity_GetBlockByNum2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NumberMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletSolidityServer).GetBlockByNum2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletSolidity_GetBlockByNum2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletSolidityServer).GetBlockByNum2(ctx, req.(*NumberMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletSolidity_GetTransactionInfoById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BytesMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletSolidityServer).GetTransactionInfoById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletSolidity_GetTransactionInfoById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletSolidityServer).GetTransactionInfoById(ctx, req.(*BytesMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletSolidity_TriggerConstantContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.TriggerSmartContract)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletSolidityServer).TriggerConstantContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletSolidity_TriggerConstantContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletSolidityServer).TriggerConstantContract(ctx, req.(*core.TriggerSmartContract))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletSolidity_GetTransactionInfoByBlockNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NumberMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletSolidityServer).GetTransactionInfoByBlockNum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletSolidity_GetTransactionInfoByBlockNum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletSolidityServer).GetTransactionInfoByBlockNum(ctx, req.(*NumberMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletSolidity_ServiceDesc is the grpc.ServiceDesc for WalletSolidity service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletSolidity_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.WalletSolidity",
+	HandlerType: (*WalletSolidityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccount",
+			Handler:    _WalletSolidity_GetAccount_Handler,
+		},
+		{
+			MethodName: "GetNowBlock2",
+			Handler:    _WalletSolidity_GetNowBlock2_Handler,
+		},
+		{
+			MethodName: "GetBlockByNum2",
+			Handler:    _WalletSolidity_GetBlockByNum2_Handler,
+		},
+		{
+			MethodName: "GetTransactionInfoById",
+			Handler:    _WalletSolidity_GetTransactionInfoById_Handler,
+		},
+		{
+			MethodName: "TriggerConstantContract",
+			Handler:    _WalletSolidity_TriggerConstantContract_Handler,
+		},
+		{
+			MethodName: "GetTransactionInfoByBlockNum",
+			Handler:    _WalletSolidity_GetTransactionInfoByBlockNum_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/api.proto",
+}
@@ -0,0 +1,68 @@
+// Package trongrpc talks to the grpc api of a java-tron node, port 50051 of
+// the fullnode and 50061 of its solidity api by default.
+//
+// The api and core packages are generated from the official protos in
+// github.com/tronprotocol/protocol, trimmed to what wallets and watchers
+// need. Messages are converted to the types of the http api so the client
+// can be used as a trongrid.Backend.
+package trongrpc
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=github.com/joshuayildiz/wallet/chain/trongrpc --go-grpc_out=. --go-grpc_opt=module=github.com/joshuayildiz/wallet/chain/trongrpc core/Tron.proto core/contract/balance_contract.proto core/contract/asset_issue_contract.proto core/contract/smart_contract.proto api/api.proto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	Net      chain.Network
+	fullnode api.WalletClient
+	solidity api.WalletSolidityClient
+	conns    []*grpc.ClientConn
+}
+
+// New creates a client for the fullnode and solidity grpc apis at the given
+// addresses, e.g. "localhost:50051" and "localhost:50061". Connections are
+// made without tls unless opts set transport credentials.
+func New(net chain.Network, fullnode, solidity string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+
+	fullnodeConn, err := grpc.NewClient(fullnode, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to fullnode %s: %w", fullnode, err)
+	}
+	solidityConn, err := grpc.NewClient(solidity, opts...)
+	if err != nil {
+		fullnodeConn.Close()
+		return nil, fmt.Errorf("connecting to solidity api %s: %w", solidity, err)
+	}
+
+	self := NewFromConns(net, fullnodeConn, solidityConn)
+	self.conns = []*grpc.ClientConn{fullnodeConn, solidityConn}
+	return self, nil
+}
+
+// NewFromConns creates a client using connections managed by the caller,
+// Close does not close them.
+func NewFromConns(net chain.Network, fullnode, solidity grpc.ClientConnInterface) *Client {
+	self := &Client{
+		Net:      net,
+		fullnode: api.NewWalletClient(fullnode),
+		solidity: api.NewWalletSolidityClient(solidity),
+	}
+	return self
+}
+
+// Close closes the connections opened by New.
+func (r *Client) Close() error {
+	var errs []error
+	for _, conn := range r.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,459 @@
+package trongrpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/api"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/core"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	alice = "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC"
+	usdt  = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+)
+
+// fakeWallet serves the methods of the fullnode api that have a handler,
+// the others are unimplemented.
+type fakeWallet struct {
+	api.UnimplementedWalletServer
+	account   func(*core.Account) (*core.Account, error)
+	create    func(*core.TransferContract) (*api.TransactionExtention, error)
+	trigger   func(*core.TriggerSmartContract) (*api.TransactionExtention, error)
+	broadcast func(*core.Transaction) (*api.Return, error)
+	infos     func(*api.NumberMessage) (*api.TransactionInfoList, error)
+}
+
+func (r *fakeWallet) GetAccount(ctx context.Context, in *core.Account) (*core.Account, error) {
+	if r.account == nil {
+		return r.UnimplementedWalletServer.GetAccount(ctx, in)
+	}
+	return r.account(in)
+}
+
+func (r *fakeWallet) CreateTransaction2(ctx context.Context, in *core.TransferContract) (*api.TransactionExtention, error) {
+	if r.create == nil {
+		return r.UnimplementedWalletServer.CreateTransaction2(ctx, in)
+	}
+	return r.create(in)
+}
+
+func (r *fakeWallet) TriggerContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	if r.trigger == nil {
+		return r.UnimplementedWalletServer.TriggerContract(ctx, in)
+	}
+	return r.trigger(in)
+}
+
+func (r *fakeWallet) BroadcastTransaction(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+	if r.broadcast == nil {
+		return r.UnimplementedWalletServer.BroadcastTransaction(ctx, in)
+	}
+	return r.broadcast(in)
+}
+
+func (r *fakeWallet) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+	if r.infos == nil {
+		return r.UnimplementedWalletServer.GetTransactionInfoByBlockNum(ctx, in)
+	}
+	return r.infos(in)
+}
+
+// fakeSolidity is fakeWallet for the solidity api.
+type fakeSolidity struct {
+	api.UnimplementedWalletSolidityServer
+	block    func(*api.NumberMessage) (*api.BlockExtention, error)
+	constant func(*core.TriggerSmartContract) (*api.TransactionExtention, error)
+	info     func(*api.BytesMessage) (*core.TransactionInfo, error)
+}
+
+func (r *fakeSolidity) GetBlockByNum2(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+	if r.block == nil {
+		return r.UnimplementedWalletSolidityServer.GetBlockByNum2(ctx, in)
+	}
+	return r.block(in)
+}
+
+func (r *fakeSolidity) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	if r.constant == nil {
+		return r.UnimplementedWalletSolidityServer.TriggerConstantContract(ctx, in)
+	}
+	return r.constant(in)
+}
+
+func (r *fakeSolidity) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	if r.info == nil {
+		return r.UnimplementedWalletSolidityServer.GetTransactionInfoById(ctx, in)
+	}
+	return r.info(in)
+}
+
+// fakeNode serves both apis in memory and returns a client connected to it.
+func fakeNode(t *testing.T, wallet *fakeWallet, solidity *fakeSolidity) *Client {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	api.RegisterWalletServer(server, wallet)
+	api.RegisterWalletSolidityServer(server, solidity)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	dial := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+	client, err := New(chain.Mainnet, "passthrough:///fullnode", "passthrough:///solidity", dial)
+	assert.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func addrBytes(addr string) []byte {
+	a := tronaddr.MustParse(addr)
+	return a[:]
+}
+
+func hexID(b byte) []byte {
+	id := make([]byte, 32)
+	id[31] = b
+	return id
+}
+
+func contract(typ core.Transaction_Contract_ContractType, value proto.Message) *core.Transaction_Contract {
+	param, err := anypb.New(value)
+	if err != nil {
+		panic(err)
+	}
+	return &core.Transaction_Contract{Type: typ, Parameter: param}
+}
+
+// transferRaw is the raw data of a trx transfer.
+func transferRaw(from, to string, amt int64) *core.TransactionRaw {
+	return &core.TransactionRaw{
+		RefBlockBytes: []byte{0x12, 0x34},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1_600_000_060_000,
+		Contract: []*core.Transaction_Contract{contract(core.Transaction_Contract_TransferContract, &core.TransferContract{
+			OwnerAddress: addrBytes(from),
+			ToAddress:    addrBytes(to),
+			Amount:       amt,
+		})},
+		Timestamp: 1_600_000_000_000,
+	}
+}
+
+func marshal(m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestBalance(t *testing.T) {
+	t.Parallel()
+
+	client := fakeNode(t, &fakeWallet{
+		account: func(in *core.Account) (*core.Account, error) {
+			assert.Equal(t, addrBytes(alice), in.Address)
+			return &core.Account{Address: in.Address, Balance: 2_000_000}, nil
+		},
+	}, &fakeSolidity{})
+
+	balance, err := client.Balance(context.Background(), alice)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2_000_000), balance)
+}
+
+func TestBlockByNum(t *testing.T) {
+	t.Parallel()
+
+	raw := transferRaw(usdt, alice, 1_000_000)
+	tx := &core.Transaction{
+		RawData:   raw,
+		Signature: [][]byte{{0xaa, 0xbb}},
+		Ret:       []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}},
+	}
+	id := sha256.Sum256(marshal(raw))
+
+	client := fakeNode(t, &fakeWallet{}, &fakeSolidity{
+		block: func(in *api.NumberMessage) (*api.BlockExtention, error) {
+			if in.Num != 100 {
+				return &api.BlockExtention{}, nil
+			}
+			return &api.BlockExtention{
+				Transactions: []*api.TransactionExtention{{Transaction: tx, Txid: id[:]}},
+				BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{
+					Timestamp:      1_600_000_003_000,
+					ParentHash:     hexID(99),
+					Number:         100,
+					WitnessAddress: addrBytes(alice),
+				}},
+				Blockid: hexID(100),
+			}, nil
+		},
+	})
+
+	b, err := client.BlockByNum(context.Background(), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(hexID(100)), b.BlockID)
+	assert.Equal(t, uint(100), b.BlockHeader.RawData.Number)
+	assert.Equal(t, int64(1_600_000_003_000), b.BlockHeader.RawData.Timestamp)
+	assert.Equal(t, hex.EncodeToString(hexID(99)), b.BlockHeader.RawData.ParentHash)
+	assert.Equal(t, tronaddr.MustParse(alice).Hex(), b.BlockHeader.RawData.WitnessAddress)
+
+	assert.Len(t, b.Transactions, 1)
+	tx0 := b.Transactions[0]
+	assert.Equal(t, hex.EncodeToString(id[:]), tx0.TxID)
+	assert.Equal(t, hex.EncodeToString(marshal(raw)), tx0.RawDataHex)
+	assert.Equal(t, []string{"aabb"}, tx0.Signature)
+	assert.Equal(t, "SUCCESS", tx0.Ret[0].ContractRet)
+	assert.Equal(t, "1234", tx0.RawData.RefBlockBytes)
+	assert.Equal(t, uint(1_600_000_000_000), tx0.RawData.Timestamp)
+
+	c := tx0.RawData.Contract[0]
+	assert.Equal(t, "TransferContract", c.Type)
+	assert.Equal(t, "type.googleapis.com/protocol.TransferContract", c.Parameter.TypeURL)
+	assert.Equal(t, tronaddr.MustParse(usdt).Hex(), c.Parameter.Value.OwnerAddress)
+	assert.Equal(t, tronaddr.MustParse(alice).Hex(), c.Parameter.Value.ToAddress)
+	assert.Equal(t, 1_000_000, c.Parameter.Value.Amount)
+
+	// unknown blocks are empty like over http
+	b, err = client.BlockByNum(context.Background(), 101)
+	assert.NoError(t, err)
+	assert.Empty(t, b.BlockID)
+}
+
+func TestTxInfoByBlockNum(t *testing.T) {
+	t.Parallel()
+
+	info := &core.TransactionInfo{
+		Id:              hexID(7),
+		Fee:             27_255_900,
+		BlockNumber:     100,
+		BlockTimeStamp:  1_600_000_003_000,
+		ContractResult:  [][]byte{{}},
+		ContractAddress: addrBytes(usdt),
+		Receipt: &core.ResourceReceipt{
+			EnergyFee:        27_255_900,
+			EnergyUsageTotal: 64_895,
+			NetUsage:         345,
+			Result:           core.Transaction_Result_SUCCESS,
+		},
+		Log: []*core.TransactionInfo_Log{{
+			Address: tronaddr.MustParse(usdt).EVMBytes(),
+			Topics:  [][]byte{hexID(1), hexID(2)},
+			Data:    hexID(3),
+		}},
+	}
+
+	client := fakeNode(t, &fakeWallet{
+		infos: func(in *api.NumberMessage) (*api.TransactionInfoList, error) {
+			return &api.TransactionInfoList{TransactionInfo: []*core.TransactionInfo{info}}, nil
+		},
+	}, &fakeSolidity{})
+
+	infos, err := client.HeadTxInfoByBlockNum(context.Background(), 100)
+	assert.NoError(t, err)
+	assert.Len(t, infos, 1)
+	assert.Equal(t, hex.EncodeToString(hexID(7)), infos[0].ID)
+	assert.Equal(t, 27_255_900, infos[0].Fee)
+	assert.Equal(t, 100, infos[0].BlockNumber)
+	assert.Equal(t, tronaddr.MustParse(usdt).Hex(), infos[0].ContractAddress)
+	assert.Equal(t, "SUCCESS", infos[0].Receipt.Result)
+	assert.Equal(t, 64_895, infos[0].Receipt.EnergyUsageTotal)
+	assert.Equal(t, 345, infos[0].Receipt.NetUsage)
+	assert.Equal(t, []trongrid.TxLog{{
+		Address: hex.EncodeToString(tronaddr.MustParse(usdt).EVMBytes()),
+		Topics:  []string{hex.EncodeToString(hexID(1)), hex.EncodeToString(hexID(2))},
+		Data:    hex.EncodeToString(hexID(3)),
+	}}, infos[0].Log)
+
+	// the solidity api does not serve it
+	_, err = client.TxInfoByBlockNum(context.Background(), 100)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestCreateTxWithPermission(t *testing.T) {
+	t.Parallel()
+
+	client := fakeNode(t, &fakeWallet{
+		create: func(in *core.TransferContract) (*api.TransactionExtention, error) {
+			raw := transferRaw(alice, usdt, in.Amount)
+			id := sha256.Sum256(marshal(raw))
+			return &api.TransactionExtention{
+				Transaction: &core.Transaction{RawData: raw},
+				Txid:        id[:],
+				Result:      &api.Return{Result: true},
+			}, nil
+		},
+	}, &fakeSolidity{})
+
+	tx, err := client.CreateTx(context.Background(), alice, usdt, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(marshal(transferRaw(alice, usdt, 5))), tx.RawDataHex)
+	assert.Equal(t, 0, tx.RawData.Contract[0].PermissionID)
+
+	tx, err = client.CreateTxWithPermission(context.Background(), alice, usdt, 5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tx.RawData.Contract[0].PermissionID)
+	assert.Equal(t, 5, tx.RawData.Contract[0].Parameter.Value.Amount)
+
+	// the id is the hash of the rewritten raw data
+	rawBytes, _ := hex.DecodeString(tx.RawDataHex)
+	id := sha256.Sum256(rawBytes)
+	assert.Equal(t, hex.EncodeToString(id[:]), tx.TxID)
+
+	var raw core.TransactionRaw
+	assert.NoError(t, proto.Unmarshal(rawBytes, &raw))
+	assert.Equal(t, int32(2), raw.Contract[0].PermissionId)
+}
+
+func TestTriggerContract(t *testing.T) {
+	t.Parallel()
+
+	transfer := abi.MustParseMethod("transfer(address,uint256) returns (bool)")
+	client := fakeNode(t, &fakeWallet{
+		trigger: func(in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			raw := &core.TransactionRaw{
+				RefBlockBytes: []byte{0x12, 0x34},
+				Contract:      []*core.Transaction_Contract{contract(core.Transaction_Contract_TriggerSmartContract, in)},
+			}
+			return &api.TransactionExtention{
+				Transaction: &core.Transaction{RawData: raw},
+				Result:      &api.Return{Result: true},
+			}, nil
+		},
+	}, &fakeSolidity{})
+
+	tx, err := client.TriggerContract(context.Background(), trongrid.Trigger{
+		From:     alice,
+		Contract: usdt,
+		Method:   transfer,
+		Args:     []any{tronaddr.MustParse(alice), big.NewInt(7)},
+		FeeLimit: 30_000_000,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(30_000_000), tx.RawData.FeeLimit)
+
+	c := tx.RawData.Contract[0]
+	assert.Equal(t, "TriggerSmartContract", c.Type)
+	assert.Equal(t, tronaddr.MustParse(alice).Hex(), c.Parameter.Value.OwnerAddress)
+	assert.Equal(t, tronaddr.MustParse(usdt).Hex(), c.Parameter.Value.ContractAddress)
+	data, _ := transfer.Pack(tronaddr.MustParse(alice), big.NewInt(7))
+	assert.Equal(t, hex.EncodeToString(data), c.Parameter.Value.Data)
+
+	// the fee limit is part of the signed raw data
+	rawBytes, _ := hex.DecodeString(tx.RawDataHex)
+	var raw core.TransactionRaw
+	assert.NoError(t, proto.Unmarshal(rawBytes, &raw))
+	assert.Equal(t, int64(30_000_000), raw.FeeLimit)
+}
+
+func TestCallConstant(t *testing.T) {
+	t.Parallel()
+
+	balanceOf := abi.MustParseMethod("balanceOf(address) returns (uint256)")
+	var callers [][]byte
+	client := fakeNode(t, &fakeWallet{}, &fakeSolidity{
+		constant: func(in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+			callers = append(callers, in.OwnerAddress)
+			args, _ := balanceOf.UnpackInput(in.Data)
+			if args[0] != tronaddr.MustParse(alice) {
+				// Error(string) "no balance"
+				revert, _ := hex.DecodeString("08c379a0" +
+					"0000000000000000000000000000000000000000000000000000000000000020" +
+					"000000000000000000000000000000000000000000000000000000000000000a" +
+					"6e6f2062616c616e636500000000000000000000000000000000000000000000")
+				return &api.TransactionExtention{
+					Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{
+						Ret:         core.Transaction_Result_FAILED,
+						ContractRet: core.Transaction_Result_REVERT,
+					}}},
+					ConstantResult: [][]byte{revert},
+					Result:         &api.Return{Result: true},
+				}, nil
+			}
+
+			out := make([]byte, 32)
+			out[31] = 42
+			return &api.TransactionExtention{
+				ConstantResult: [][]byte{out},
+				Result:         &api.Return{Result: true},
+			}, nil
+		},
+	})
+
+	values, err := client.CallConstant(context.Background(), "", usdt, balanceOf, tronaddr.MustParse(alice))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), values[0])
+
+	_, err = client.CallConstant(context.Background(), "", usdt, balanceOf, tronaddr.MustParse(usdt))
+	var revertErr *trongrid.RevertError
+	assert.True(t, errors.As(err, &revertErr))
+	assert.Equal(t, "no balance", revertErr.Reason)
+
+	// usdt helpers work over any backend and call as the holder
+	balance, err := trongrid.USDTBalance(context.Background(), client, alice)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(42), balance)
+	assert.Equal(t, [][]byte{nil, nil, addrBytes(alice)}, callers)
+}
+
+func TestBroadcast(t *testing.T) {
+	t.Parallel()
+
+	raw := marshal(transferRaw(alice, usdt, 5))
+	client := fakeNode(t, &fakeWallet{
+		broadcast: func(in *core.Transaction) (*api.Return, error) {
+			assert.Equal(t, raw, marshal(in.RawData))
+			if len(in.Signature) == 0 {
+				return &api.Return{Code: api.Return_SIGERROR, Message: []byte("Validate signature error")}, nil
+			}
+			return &api.Return{Result: true}, nil
+		},
+	}, &fakeSolidity{})
+
+	id := sha256.Sum256(raw)
+	tx := trongrid.Tx{TxID: hex.EncodeToString(id[:]), RawDataHex: hex.EncodeToString(raw)}
+	_, err := client.Broadcast(context.Background(), tx)
+	assert.EqualError(t, err, "broadcast result SIGERROR: Validate signature error")
+
+	tx.Signature = []string{"aabb"}
+	hash, err := client.Broadcast(context.Background(), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.TxID, hash)
+
+	// raw data that would not be sent as signed is rejected
+	tx.RawDataHex = "0a021234" + "0a021234"
+	_, err = client.Broadcast(context.Background(), tx)
+	assert.ErrorContains(t, err, "not canonically encoded")
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	client := fakeNode(t, &fakeWallet{}, &fakeSolidity{
+		info: func(in *api.BytesMessage) (*core.TransactionInfo, error) {
+			return nil, status.Error(codes.Unavailable, "node is syncing")
+		},
+	})
+
+	_, err := client.TxInfoByID(context.Background(), hex.EncodeToString(hexID(1)))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.EqualError(t, err, "fetching tx by id "+hex.EncodeToString(hexID(1))+": rpc error: code = Unavailable desc = node is syncing")
+}
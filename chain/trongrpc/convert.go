@@ -0,0 +1,192 @@
+package trongrpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/api"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// Conversion of the generated messages into the types of the http api. Byte
+// fields become hex like the http api returns them with visible set to false.
+
+// convertBlock converts a BlockExtention, nodes return an empty one for
+// unknown blocks.
+func convertBlock(ext *api.BlockExtention) (*trongrid.Block, error) {
+	var b trongrid.Block
+	b.BlockID = hex.EncodeToString(ext.GetBlockid())
+
+	raw := ext.GetBlockHeader().GetRawData()
+	b.BlockHeader.RawData.Number = uint(raw.GetNumber())
+	b.BlockHeader.RawData.Timestamp = raw.GetTimestamp()
+	b.BlockHeader.RawData.ParentHash = hex.EncodeToString(raw.GetParentHash())
+	b.BlockHeader.RawData.TxTrieRoot = hex.EncodeToString(raw.GetTxTrieRoot())
+	b.BlockHeader.RawData.WitnessAddress = hex.EncodeToString(raw.GetWitnessAddress())
+
+	for _, txExt := range ext.GetTransactions() {
+		tx, err := convertTx(txExt.GetTransaction())
+		if err != nil {
+			return nil, err
+		}
+		b.Transactions = append(b.Transactions, tx)
+	}
+	return &b, nil
+}
+
+// convertTx converts a Transaction. The id is not part of the message, it is
+// the sha256 of the raw data.
+func convertTx(in *core.Transaction) (trongrid.Tx, error) {
+	raw, err := proto.Marshal(in.GetRawData())
+	if err != nil {
+		return trongrid.Tx{}, fmt.Errorf("encoding raw data: %w", err)
+	}
+	id := sha256.Sum256(raw)
+
+	tx := trongrid.Tx{
+		RawDataHex: hex.EncodeToString(raw),
+		TxID:       hex.EncodeToString(id[:]),
+	}
+	tx.RawData, err = convertTxRaw(in.GetRawData())
+	if err != nil {
+		return trongrid.Tx{}, err
+	}
+	for _, sig := range in.GetSignature() {
+		tx.Signature = append(tx.Signature, hex.EncodeToString(sig))
+	}
+	for _, ret := range in.GetRet() {
+		r := trongrid.TxRet{Fee: int(ret.GetFee())}
+		if ret.GetRet() == core.Transaction_Result_FAILED {
+			r.Ret = "FAILED"
+		}
+		r.ContractRet = contractResult(ret.GetContractRet())
+		tx.Ret = append(tx.Ret, r)
+	}
+	return tx, nil
+}
+
+func convertTxRaw(in *core.TransactionRaw) (trongrid.TxRawData, error) {
+	raw := trongrid.TxRawData{
+		RefBlockBytes: hex.EncodeToString(in.GetRefBlockBytes()),
+		RefBlockHash:  hex.EncodeToString(in.GetRefBlockHash()),
+		Expiration:    uint(in.GetExpiration()),
+		Timestamp:     uint(in.GetTimestamp()),
+		FeeLimit:      uint(in.GetFeeLimit()),
+		Data:          hex.EncodeToString(in.GetData()),
+	}
+	for _, c := range in.GetContract() {
+		contract, err := convertContract(c)
+		if err != nil {
+			return trongrid.TxRawData{}, err
+		}
+		raw.Contract = append(raw.Contract, contract)
+	}
+	return raw, nil
+}
+
+func convertContract(in *core.Transaction_Contract) (trongrid.Contract, error) {
+	var c trongrid.Contract
+	c.Type = in.GetType().String()
+	c.Parameter.TypeURL = in.GetParameter().GetTypeUrl()
+	c.PermissionID = int(in.GetPermissionId())
+
+	value := in.GetParameter().GetValue()
+	v := &c.Parameter.Value
+	switch in.GetType() {
+	case core.Transaction_Contract_TransferContract:
+		var transfer core.TransferContract
+		err := proto.Unmarshal(value, &transfer)
+		if err != nil {
+			return trongrid.Contract{}, fmt.Errorf("decoding %s: %w", c.Type, err)
+		}
+		v.OwnerAddress = hex.EncodeToString(transfer.GetOwnerAddress())
+		v.ToAddress = hex.EncodeToString(transfer.GetToAddress())
+		v.Amount = int(transfer.GetAmount())
+	case core.Transaction_Contract_TransferAssetContract:
+		var transfer core.TransferAssetContract
+		err := proto.Unmarshal(value, &transfer)
+		if err != nil {
+			return trongrid.Contract{}, fmt.Errorf("decoding %s: %w", c.Type, err)
+		}
+		v.AssetName = hex.EncodeToString(transfer.GetAssetName())
+		v.OwnerAddress = hex.EncodeToString(transfer.GetOwnerAddress())
+		v.ToAddress = hex.EncodeToString(transfer.GetToAddress())
+		v.Amount = int(transfer.GetAmount())
+	case core.Transaction_Contract_TriggerSmartContract:
+		var trigger core.TriggerSmartContract
+		err := proto.Unmarshal(value, &trigger)
+		if err != nil {
+			return trongrid.Contract{}, fmt.Errorf("decoding %s: %w", c.Type, err)
+		}
+		v.OwnerAddress = hex.EncodeToString(trigger.GetOwnerAddress())
+		v.ContractAddress = hex.EncodeToString(trigger.GetContractAddress())
+		v.Data = hex.EncodeToString(trigger.GetData())
+		v.CallValue = int(trigger.GetCallValue())
+		v.CallTokenValue = int(trigger.GetCallTokenValue())
+		v.TokenID = int(trigger.GetTokenId())
+	}
+	return c, nil
+}
+
+// contractResult leaves the default value empty like the http api omits it.
+func contractResult(res core.Transaction_ResultContractResult) string {
+	if res == core.Transaction_Result_DEFAULT {
+		return ""
+	}
+	return res.String()
+}
+
+func convertTxInfo(in *core.TransactionInfo) *trongrid.TxInfo {
+	info := trongrid.TxInfo{
+		ID:              hex.EncodeToString(in.GetId()),
+		Fee:             int(in.GetFee()),
+		BlockNumber:     int(in.GetBlockNumber()),
+		BlockTimeStamp:  in.GetBlockTimeStamp(),
+		ContractAddress: hex.EncodeToString(in.GetContractAddress()),
+	}
+	for _, res := range in.GetContractResult() {
+		info.ContractResult = append(info.ContractResult, hex.EncodeToString(res))
+	}
+
+	receipt := in.GetReceipt()
+	info.Receipt.EnergyFee = int(receipt.GetEnergyFee())
+	info.Receipt.OriginEnergyUsage = int(receipt.GetOriginEnergyUsage())
+	info.Receipt.EnergyUsageTotal = int(receipt.GetEnergyUsageTotal())
+	info.Receipt.NetUsage = int(receipt.GetNetUsage())
+	info.Receipt.NetFee = int(receipt.GetNetFee())
+	info.Receipt.Result = contractResult(receipt.GetResult())
+
+	for _, l := range in.GetLog() {
+		log := trongrid.TxLog{
+			Address: hex.EncodeToString(l.GetAddress()),
+			Data:    hex.EncodeToString(l.GetData()),
+		}
+		for _, topic := range l.GetTopics() {
+			log.Topics = append(log.Topics, hex.EncodeToString(topic))
+		}
+		info.Log = append(info.Log, log)
+	}
+	return &info
+}
+
+// rewriteRaw sets the fee limit and the permission of the contracts in the
+// raw data of a transaction, neither can be passed to the api when creating
+// it. Fields are encoded in order of their numbers like java-tron does, so
+// the id is the hash of the result.
+func rewriteRaw(tx *core.Transaction, feeLimit uint, permissionID int) {
+	raw := tx.GetRawData()
+	if raw == nil {
+		return
+	}
+	if feeLimit != 0 {
+		raw.FeeLimit = int64(feeLimit)
+	}
+	if permissionID != 0 {
+		for _, c := range raw.Contract {
+			c.PermissionId = int32(permissionID)
+		}
+	}
+}
@@ -0,0 +1,1583 @@
+// Subset of core/Tron.proto of github.com/tronprotocol/protocol, messages and
+// fields keep their official names and numbers. Fields that are left out are
+// kept as unknown fields when decoding.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: core/Tron.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AccountType int32
+
+const (
+	AccountType_Normal     AccountType = 0
+	AccountType_AssetIssue AccountType = 1
+	AccountType_Contract   AccountType = 2
+)
+
+// Enum value maps for AccountType.
+var (
+	AccountType_name = map[int32]string{
+		0: "Normal",
+		1: "AssetIssue",
+		2: "Contract",
+	}
+	AccountType_value = map[string]int32{
+		"Normal":     0,
+		"AssetIssue": 1,
+		"Contract":   2,
+	}
+)
+
+func (x AccountType) Enum() *AccountType {
+	p := new(AccountType)
+	*p = x
+	return p
+}
+
+func (x AccountType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountType) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_Tron_proto_enumTypes[0].Descriptor()
+}
+
+func (AccountType) Type() protoreflect.EnumType {
+	return &file_core_Tron_proto_enumTypes[0]
+}
+
+func (x AccountType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountType.Descriptor instead.
+func (AccountType) EnumDescriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{0}
+}
+
+type Transaction_Contract_ContractType int32
+
+const (
+	Transaction_Contract_AccountCreateContract           Transaction_Contract_ContractType = 0
+	Transaction_Contract_TransferContract                Transaction_Contract_ContractType = 1
+	Transaction_Contract_TransferAssetContract           Transaction_Contract_ContractType = 2
+	Transaction_Contract_VoteAssetContract               Transaction_Contract_ContractType = 3
+	Transaction_Contract_VoteWitnessContract             Transaction_Contract_ContractType = 4
+	Transaction_Contract_WitnessCreateContract           Transaction_Contract_ContractType = 5
+	Transaction_Contract_AssetIssueContract              Transaction_Contract_ContractType = 6
+	Transaction_Contract_WitnessUpdateContract           Transaction_Contract_ContractType = 8
+	Transaction_Contract_ParticipateAssetIssueContract   Transaction_Contract_ContractType = 9
+	Transaction_Contract_AccountUpdateContract           Transaction_Contract_ContractType = 10
+	Transaction_Contract_FreezeBalanceContract           Transaction_Contract_ContractType = 11
+	Transaction_Contract_UnfreezeBalanceContract         Transaction_Contract_ContractType = 12
+	Transaction_Contract_WithdrawBalanceContract         Transaction_Contract_ContractType = 13
+	Transaction_Contract_UnfreezeAssetContract           Transaction_Contract_ContractType = 14
+	Transaction_Contract_UpdateAssetContract             Transaction_Contract_ContractType = 15
+	Transaction_Contract_ProposalCreateContract          Transaction_Contract_ContractType = 16
+	Transaction_Contract_ProposalApproveContract         Transaction_Contract_ContractType = 17
+	Transaction_Contract_ProposalDeleteContract          Transaction_Contract_ContractType = 18
+	Transaction_Contract_SetAccountIdContract            Transaction_Contract_ContractType = 19
+	Transaction_Contract_CustomContract                  Transaction_Contract_ContractType = 20
+	Transaction_Contract_CreateSmartContract             Transaction_Contract_ContractType = 30
+	Transaction_Contract_TriggerSmartContract            Transaction_Contract_ContractType = 31
+	Transaction_Contract_GetContract                     Transaction_Contract_ContractType = 32
+	Transaction_Contract_UpdateSettingContract           Transaction_Contract_ContractType = 33
+	Transaction_Contract_ExchangeCreateContract          Transaction_Contract_ContractType = 41
+	Transaction_Contract_ExchangeInjectContract          Transaction_Contract_ContractType = 42
+	Transaction_Contract_ExchangeWithdrawContract        Transaction_Contract_ContractType = 43
+	Transaction_Contract_ExchangeTransactionContract     Transaction_Contract_ContractType = 44
+	Transaction_Contract_UpdateEnergyLimitContract       Transaction_Contract_ContractType = 45
+	Transaction_Contract_AccountPermissionUpdateContract Transaction_Contract_ContractType = 46
+	Transaction_Contract_ClearABIContract                Transaction_Contract_ContractType = 48
+	Transaction_Contract_UpdateBrokerageContract         Transaction_Contract_ContractType = 49
+	Transaction_Contract_ShieldedTransferContract        Transaction_Contract_ContractType = 51
+	Transaction_Contract_MarketSellAssetContract         Transaction_Contract_ContractType = 52
+	Transaction_Contract_MarketCancelOrderContract       Transaction_Contract_ContractType = 53
+	Transaction_Contract_FreezeBalanceV2Contract         Transaction_Contract_ContractType = 54
+	Transaction_Contract_UnfreezeBalanceV2Contract       Transaction_Contract_ContractType = 55
+	Transaction_Contract_WithdrawExpireUnfreezeContract  Transaction_Contract_ContractType = 56
+	Transaction_Contract_DelegateResourceContract        Transaction_Contract_ContractType = 57
+	Transaction_Contract_UnDelegateResourceContract      Transaction_Contract_ContractType = 58
+	Transaction_Contract_CancelAllUnfreezeV2Contract     Transaction_Contract_ContractType = 59
+)
+
+// Enum value maps for Transaction_Contract_ContractType.
+var (
+	Transaction_Contract_ContractType_name = map[int32]string{
+		0:  "AccountCreateContract",
+		1:  "TransferContract",
+		2:  "TransferAssetContract",
+		3:  "VoteAssetContract",
+		4:  "VoteWitnessContract",
+		5:  "WitnessCreateContract",
+		6:  "AssetIssueContract",
+		8:  "WitnessUpdateContract",
+		9:  "ParticipateAssetIssueContract",
+		10: "AccountUpdateContract",
+		11: "FreezeBalanceContract",
+		12: "UnfreezeBalanceContract",
+		13: "WithdrawBalanceContract",
+		14: "UnfreezeAssetContract",
+		15: "UpdateAssetContract",
+		16: "ProposalCreateContract",
+		17: "ProposalApproveContract",
+		18: "ProposalDeleteContract",
+		19: "SetAccountIdContract",
+		20: "CustomContract",
+		30: "CreateSmartContract",
+		31: "TriggerSmartContract",
+		32: "GetContract",
+		33: "UpdateSettingContract",
+		41: "ExchangeCreateContract",
+		42: "ExchangeInjectContract",
+		43: "ExchangeWithdrawContract",
+		44: "ExchangeTransactionContract",
+		45: "UpdateEnergyLimitContract",
+		46: "AccountPermissionUpdateContract",
+		48: "ClearABIContract",
+		49: "UpdateBrokerageContract",
+		51: "ShieldedTransferContract",
+		52: "MarketSellAssetContract",
+		53: "MarketCancelOrderContract",
+		54: "FreezeBalanceV2Contract",
+		55: "UnfreezeBalanceV2Contract",
+		56: "WithdrawExpireUnfreezeContract",
+		57: "DelegateResourceContract",
+		58: "UnDelegateResourceContract",
+		59: "CancelAllUnfreezeV2Contract",
+	}
+	Transaction_Contract_ContractType_value = map[string]int32{
+		"AccountCreateContract":           0,
+		"TransferContract":                1,
+		"TransferAssetContract":           2,
+		"VoteAssetContract":               3,
+		"VoteWitnessContract":             4,
+		"WitnessCreateContract":           5,
+		"AssetIssueContract":              6,
+		"WitnessUpdateContract":           8,
+		"ParticipateAssetIssueContract":   9,
+		"AccountUpdateContract":           10,
+		"FreezeBalanceContract":           11,
+		"UnfreezeBalanceContract":         12,
+		"WithdrawBalanceContract":         13,
+		"UnfreezeAssetContract":           14,
+		"UpdateAssetContract":             15,
+		"ProposalCreateContract":          16,
+		"ProposalApproveContract":         17,
+		"ProposalDeleteContract":          18,
+		"SetAccountIdContract":            19,
+		"CustomContract":                  20,
+		"CreateSmartContract":             30,
+		"TriggerSmartContract":            31,
+		"GetContract":                     32,
+		"UpdateSettingContract":           33,
+		"ExchangeCreateContract":          41,
+		"ExchangeInjectContract":          42,
+		"ExchangeWithdrawContract":        43,
+		"ExchangeTransactionContract":     44,
+		"UpdateEnergyLimitContract":       45,
+		"AccountPermissionUpdateContract": 46,
+		"ClearABIContract":                48,
+		"UpdateBrokerageContract":         49,
+		"ShieldedTransferContract":        51,
+		"MarketSellAssetContract":         52,
+		"MarketCancelOrderContract":       53,
+		"FreezeBalanceV2Contract":         54,
+		"UnfreezeBalanceV2Contract":       55,
+		"WithdrawExpireUnfreezeContract":  56,
+		"DelegateResourceContract":        57,
+		"UnDelegateResourceContract":      58,
+		"CancelAllUnfreezeV2Contract":     59,
+	}
+)
+
+func (x Transaction_Contract_ContractType) Enum() *Transaction_Contract_ContractType {
+	p := new(Transaction_Contract_ContractType)
+	*p = x
+	return p
+}
+
+func (x Transaction_Contract_ContractType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Transaction_Contract_ContractType) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_Tron_proto_enumTypes[1].Descriptor()
+}
+
+func (Transaction_Contract_ContractType) Type() protoreflect.EnumType {
+	return &file_core_Tron_proto_enumTypes[1]
+}
+
+func (x Transaction_Contract_ContractType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Transaction_Contract_ContractType.Descriptor instead.
+func (Transaction_Contract_ContractType) EnumDescriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4, 0, 0}
+}
+
+type Transaction_ResultCode int32
+
+const (
+	Transaction_Result_SUCESS Transaction_ResultCode = 0
+	Transaction_Result_FAILED Transaction_ResultCode = 1
+)
+
+// Enum value maps for Transaction_ResultCode.
+var (
+	Transaction_ResultCode_name = map[int32]string{
+		0: "SUCESS",
+		1: "FAILED",
+	}
+	Transaction_ResultCode_value = map[string]int32{
+		"SUCESS": 0,
+		"FAILED": 1,
+	}
+)
+
+func (x Transaction_ResultCode) Enum() *Transaction_ResultCode {
+	p := new(Transaction_ResultCode)
+	*p = x
+	return p
+}
+
+func (x Transaction_ResultCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Transaction_ResultCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_Tron_proto_enumTypes[2].Descriptor()
+}
+
+func (Transaction_ResultCode) Type() protoreflect.EnumType {
+	return &file_core_Tron_proto_enumTypes[2]
+}
+
+func (x Transaction_ResultCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Transaction_ResultCode.Descriptor instead.
+func (Transaction_ResultCode) EnumDescriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4, 1, 0}
+}
+
+type Transaction_ResultContractResult int32
+
+const (
+	Transaction_Result_DEFAULT              Transaction_ResultContractResult = 0
+	Transaction_Result_SUCCESS              Transaction_ResultContractResult = 1
+	Transaction_Result_REVERT               Transaction_ResultContractResult = 2
+	Transaction_Result_BAD_JUMP_DESTINATION Transaction_ResultContractResult = 3
+	Transaction_Result_OUT_OF_MEMORY        Transaction_ResultContractResult = 4
+	Transaction_Result_PRECOMPILED_CONTRACT Transaction_ResultContractResult = 5
+	Transaction_Result_STACK_TOO_SMALL      Transaction_ResultContractResult = 6
+	Transaction_Result_STACK_TOO_LARGE      Transaction_ResultContractResult = 7
+	Transaction_Result_ILLEGAL_OPERATION    Transaction_ResultContractResult = 8
+	Transaction_Result_STACK_OVERFLOW       Transaction_ResultContractResult = 9
+	Transaction_Result_OUT_OF_ENERGY        Transaction_ResultContractResult = 10
+	Transaction_Result_OUT_OF_TIME          Transaction_ResultContractResult = 11
+	Transaction_Result_JVM_STACK_OVER_FLOW  Transaction_ResultContractResult = 12
+	Transaction_Result_UNKNOWN              Transaction_ResultContractResult = 13
+	Transaction_Result_TRANSFER_FAILED      Transaction_ResultContractResult = 14
+	Transaction_Result_INVALID_CODE         Transaction_ResultContractResult = 15
+)
+
+// Enum value maps for Transaction_ResultContractResult.
+var (
+	Transaction_ResultContractResult_name = map[int32]string{
+		0:  "DEFAULT",
+		1:  "SUCCESS",
+		2:  "REVERT",
+		3:  "BAD_JUMP_DESTINATION",
+		4:  "OUT_OF_MEMORY",
+		5:  "PRECOMPILED_CONTRACT",
+		6:  "STACK_TOO_SMALL",
+		7:  "STACK_TOO_LARGE",
+		8:  "ILLEGAL_OPERATION",
+		9:  "STACK_OVERFLOW",
+		10: "OUT_OF_ENERGY",
+		11: "OUT_OF_TIME",
+		12: "JVM_STACK_OVER_FLOW",
+		13: "UNKNOWN",
+		14: "TRANSFER_FAILED",
+		15: "INVALID_CODE",
+	}
+	Transaction_ResultContractResult_value = map[string]int32{
+		"DEFAULT":              0,
+		"SUCCESS":              1,
+		"REVERT":               2,
+		"BAD_JUMP_DESTINATION": 3,
+		"OUT_OF_MEMORY":        4,
+		"PRECOMPILED_CONTRACT": 5,
+		"STACK_TOO_SMALL":      6,
+		"STACK_TOO_LARGE":      7,
+		"ILLEGAL_OPERATION":    8,
+		"STACK_OVERFLOW":       9,
+		"OUT_OF_ENERGY":        10,
+		"OUT_OF_TIME":          11,
+		"JVM_STACK_OVER_FLOW":  12,
+		"UNKNOWN":              13,
+		"TRANSFER_FAILED":      14,
+		"INVALID_CODE":         15,
+	}
+)
+
+func (x Transaction_ResultContractResult) Enum() *Transaction_ResultContractResult {
+	p := new(Transaction_ResultContractResult)
+	*p = x
+	return p
+}
+
+func (x Transaction_ResultContractResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Transaction_ResultContractResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_Tron_proto_enumTypes[3].Descriptor()
+}
+
+func (Transaction_ResultContractResult) Type() protoreflect.EnumType {
+	return &file_core_Tron_proto_enumTypes[3]
+}
+
+func (x Transaction_ResultContractResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Transaction_ResultContractResult.Descriptor instead.
+func (Transaction_ResultContractResult) EnumDescriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4, 1, 1}
+}
+
+type TransactionInfoCode int32
+
+const (
+	TransactionInfo_SUCESS TransactionInfoCode = 0
+	TransactionInfo_FAILED TransactionInfoCode = 1
+)
+
+// Enum value maps for TransactionInfoCode.
+var (
+	TransactionInfoCode_name = map[int32]string{
+		0: "SUCESS",
+		1: "FAILED",
+	}
+	TransactionInfoCode_value = map[string]int32{
+		"SUCESS": 0,
+		"FAILED": 1,
+	}
+)
+
+func (x TransactionInfoCode) Enum() *TransactionInfoCode {
+	p := new(TransactionInfoCode)
+	*p = x
+	return p
+}
+
+func (x TransactionInfoCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionInfoCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_Tron_proto_enumTypes[4].Descriptor()
+}
+
+func (TransactionInfoCode) Type() protoreflect.EnumType {
+	return &file_core_Tron_proto_enumTypes[4]
+}
+
+func (x TransactionInfoCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionInfoCode.Descriptor instead.
+func (TransactionInfoCode) EnumDescriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type AccountId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          []byte                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       []byte                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountId) Reset() {
+	*x = AccountId{}
+	mi := &file_core_Tron_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountId) ProtoMessage() {}
+
+func (x *AccountId) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountId.ProtoReflect.Descriptor instead.
+func (*AccountId) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AccountId) GetName() []byte {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *AccountId) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type Authority struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Account        *AccountId             `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	PermissionName []byte                 `protobuf:"bytes,2,opt,name=permission_name,json=permissionName,proto3" json:"permission_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Authority) Reset() {
+	*x = Authority{}
+	mi := &file_core_Tron_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Authority) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authority) ProtoMessage() {}
+
+func (x *Authority) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authority.ProtoReflect.Descriptor instead.
+func (*Authority) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Authority) GetAccount() *AccountId {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *Authority) GetPermissionName() []byte {
+	if x != nil {
+		return x.PermissionName
+	}
+	return nil
+}
+
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   []byte                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Type          AccountType            `protobuf:"varint,2,opt,name=type,proto3,enum=protocol.AccountType" json:"type,omitempty"`
+	Address       []byte                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Balance       int64                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_core_Tron_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Account) GetAccountName() []byte {
+	if x != nil {
+		return x.AccountName
+	}
+	return nil
+}
+
+func (x *Account) GetType() AccountType {
+	if x != nil {
+		return x.Type
+	}
+	return AccountType_Normal
+}
+
+func (x *Account) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Account) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type ResourceReceipt struct {
+	state              protoimpl.MessageState           `protogen:"open.v1"`
+	EnergyUsage        int64                            `protobuf:"varint,1,opt,name=energy_usage,json=energyUsage,proto3" json:"energy_usage,omitempty"`
+	EnergyFee          int64                            `protobuf:"varint,2,opt,name=energy_fee,json=energyFee,proto3" json:"energy_fee,omitempty"`
+	OriginEnergyUsage  int64                            `protobuf:"varint,3,opt,name=origin_energy_usage,json=originEnergyUsage,proto3" json:"origin_energy_usage,omitempty"`
+	EnergyUsageTotal   int64                            `protobuf:"varint,4,opt,name=energy_usage_total,json=energyUsageTotal,proto3" json:"energy_usage_total,omitempty"`
+	NetUsage           int64                            `protobuf:"varint,5,opt,name=net_usage,json=netUsage,proto3" json:"net_usage,omitempty"`
+	NetFee             int64                            `protobuf:"varint,6,opt,name=net_fee,json=netFee,proto3" json:"net_fee,omitempty"`
+	Result             Transaction_ResultContractResult `protobuf:"varint,7,opt,name=result,proto3,enum=protocol.Transaction_ResultContractResult" json:"result,omitempty"`
+	EnergyPenaltyTotal int64                            `protobuf:"varint,8,opt,name=energy_penalty_total,json=energyPenaltyTotal,proto3" json:"energy_penalty_total,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ResourceReceipt) Reset() {
+	*x = ResourceReceipt{}
+	mi := &file_core_Tron_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceReceipt) ProtoMessage() {}
+
+func (x *ResourceReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceReceipt.ProtoReflect.Descriptor instead.
+func (*ResourceReceipt) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ResourceReceipt) GetEnergyUsage() int64 {
+	if x != nil {
+		return x.EnergyUsage
+	}
+	return 0
+}
+
+func (x *ResourceReceipt) GetEnergyFee() int64 {
+	if x != nil {
+		return x.EnergyFee
+	}
+	return 0
+}
+
+func (x *ResourceReceipt) GetOriginEnergyUsage() int64 {
+	if x != nil {
+		return x.OriginEnergyUsage
+	}
+	return 0
+}
+
+func (x *ResourceReceipt) GetEnergyUsageTotal() int64 {
+	if x != nil {
+		return x.EnergyUsageTotal
+	}
+	return 0
+}
+
+func (x *ResourceReceipt) GetNetUsage() int64 {
+	if x != nil {
+		return x.NetUsage
+	}
+	return 0
+}
+
+func (x *ResourceReceipt) GetNetFee() int64 {
+	if x != nil {
+		return x.NetFee
+	}
+	return 0
+}
+
+func (x *ResourceReceipt) GetResult() Transaction_ResultContractResult {
+	if x != nil {
+		return x.Result
+	}
+	return Transaction_Result_DEFAULT
+}
+
+func (x *ResourceReceipt) GetEnergyPenaltyTotal() int64 {
+	if x != nil {
+		return x.EnergyPenaltyTotal
+	}
+	return 0
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawData       *TransactionRaw        `protobuf:"bytes,1,opt,name=raw_data,json=rawData,proto3" json:"raw_data,omitempty"`
+	Signature     [][]byte               `protobuf:"bytes,2,rep,name=signature,proto3" json:"signature,omitempty"`
+	Ret           []*Transaction_Result  `protobuf:"bytes,5,rep,name=ret,proto3" json:"ret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_core_Tron_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Transaction) GetRawData() *TransactionRaw {
+	if x != nil {
+		return x.RawData
+	}
+	return nil
+}
+
+func (x *Transaction) GetSignature() [][]byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *Transaction) GetRet() []*Transaction_Result {
+	if x != nil {
+		return x.Ret
+	}
+	return nil
+}
+
+type TransactionInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fee             int64                  `protobuf:"varint,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	BlockNumber     int64                  `protobuf:"varint,3,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	BlockTimeStamp  int64                  `protobuf:"varint,4,opt,name=blockTimeStamp,proto3" json:"blockTimeStamp,omitempty"`
+	ContractResult  [][]byte               `protobuf:"bytes,5,rep,name=contractResult,proto3" json:"contractResult,omitempty"`
+	ContractAddress []byte                 `protobuf:"bytes,6,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	Receipt         *ResourceReceipt       `protobuf:"bytes,7,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Log             []*TransactionInfo_Log `protobuf:"bytes,8,rep,name=log,proto3" json:"log,omitempty"`
+	Result          TransactionInfoCode    `protobuf:"varint,9,opt,name=result,proto3,enum=protocol.TransactionInfoCode" json:"result,omitempty"`
+	ResMessage      []byte                 `protobuf:"bytes,10,opt,name=resMessage,proto3" json:"resMessage,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransactionInfo) Reset() {
+	*x = TransactionInfo{}
+	mi := &file_core_Tron_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionInfo) ProtoMessage() {}
+
+func (x *TransactionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionInfo.ProtoReflect.Descriptor instead.
+func (*TransactionInfo) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransactionInfo) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *TransactionInfo) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetBlockTimeStamp() int64 {
+	if x != nil {
+		return x.BlockTimeStamp
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetContractResult() [][]byte {
+	if x != nil {
+		return x.ContractResult
+	}
+	return nil
+}
+
+func (x *TransactionInfo) GetContractAddress() []byte {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return nil
+}
+
+func (x *TransactionInfo) GetReceipt() *ResourceReceipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *TransactionInfo) GetLog() []*TransactionInfo_Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *TransactionInfo) GetResult() TransactionInfoCode {
+	if x != nil {
+		return x.Result
+	}
+	return TransactionInfo_SUCESS
+}
+
+func (x *TransactionInfo) GetResMessage() []byte {
+	if x != nil {
+		return x.ResMessage
+	}
+	return nil
+}
+
+type BlockHeader struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RawData          *BlockHeaderRaw        `protobuf:"bytes,1,opt,name=raw_data,json=rawData,proto3" json:"raw_data,omitempty"`
+	WitnessSignature []byte                 `protobuf:"bytes,2,opt,name=witness_signature,json=witnessSignature,proto3" json:"witness_signature,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BlockHeader) Reset() {
+	*x = BlockHeader{}
+	mi := &file_core_Tron_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeader) ProtoMessage() {}
+
+func (x *BlockHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeader.ProtoReflect.Descriptor instead.
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BlockHeader) GetRawData() *BlockHeaderRaw {
+	if x != nil {
+		return x.RawData
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetWitnessSignature() []byte {
+	if x != nil {
+		return x.WitnessSignature
+	}
+	return nil
+}
+
+type Transaction_Contract struct {
+	state         protoimpl.MessageState            `protogen:"open.v1"`
+	Type          Transaction_Contract_ContractType `protobuf:"varint,1,opt,name=type,proto3,enum=protocol.Transaction_Contract_ContractType" json:"type,omitempty"`
+	Parameter     *anypb.Any                        `protobuf:"bytes,2,opt,name=parameter,proto3" json:"parameter,omitempty"`
+	Provider      []byte                            `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	ContractName  []byte                            `protobuf:"bytes,4,opt,name=ContractName,proto3" json:"ContractName,omitempty"`
+	PermissionId  int32                             `protobuf:"varint,5,opt,name=Permission_id,json=PermissionId,proto3" json:"Permission_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction_Contract) Reset() {
+	*x = Transaction_Contract{}
+	mi := &file_core_Tron_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction_Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction_Contract) ProtoMessage() {}
+
+func (x *Transaction_Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction_Contract.ProtoReflect.Descriptor instead.
+func (*Transaction_Contract) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Transaction_Contract) GetType() Transaction_Contract_ContractType {
+	if x != nil {
+		return x.Type
+	}
+	return Transaction_Contract_AccountCreateContract
+}
+
+func (x *Transaction_Contract) GetParameter() *anypb.Any {
+	if x != nil {
+		return x.Parameter
+	}
+	return nil
+}
+
+func (x *Transaction_Contract) GetProvider() []byte {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+func (x *Transaction_Contract) GetContractName() []byte {
+	if x != nil {
+		return x.ContractName
+	}
+	return nil
+}
+
+func (x *Transaction_Contract) GetPermissionId() int32 {
+	if x != nil {
+		return x.PermissionId
+	}
+	return 0
+}
+
+type Transaction_Result struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	Fee           int64                            `protobuf:"varint,1,opt,name=fee,proto3" json:"fee,omitempty"`
+	Ret           Transaction_ResultCode           `protobuf:"varint,2,opt,name=ret,proto3,enum=protocol.Transaction_ResultCode" json:"ret,omitempty"`
+	ContractRet   Transaction_ResultContractResult `protobuf:"varint,3,opt,name=contractRet,proto3,enum=protocol.Transaction_ResultContractResult" json:"contractRet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction_Result) Reset() {
+	*x = Transaction_Result{}
+	mi := &file_core_Tron_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction_Result) ProtoMessage() {}
+
+func (x *Transaction_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction_Result.ProtoReflect.Descriptor instead.
+func (*Transaction_Result) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Transaction_Result) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Transaction_Result) GetRet() Transaction_ResultCode {
+	if x != nil {
+		return x.Ret
+	}
+	return Transaction_Result_SUCESS
+}
+
+func (x *Transaction_Result) GetContractRet() Transaction_ResultContractResult {
+	if x != nil {
+		return x.ContractRet
+	}
+	return Transaction_Result_DEFAULT
+}
+
+type TransactionRaw struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	RefBlockBytes []byte                  `protobuf:"bytes,1,opt,name=ref_block_bytes,json=refBlockBytes,proto3" json:"ref_block_bytes,omitempty"`
+	RefBlockNum   int64                   `protobuf:"varint,3,opt,name=ref_block_num,json=refBlockNum,proto3" json:"ref_block_num,omitempty"`
+	RefBlockHash  []byte                  `protobuf:"bytes,4,opt,name=ref_block_hash,json=refBlockHash,proto3" json:"ref_block_hash,omitempty"`
+	Expiration    int64                   `protobuf:"varint,8,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Auths         []*Authority            `protobuf:"bytes,9,rep,name=auths,proto3" json:"auths,omitempty"`
+	Data          []byte                  `protobuf:"bytes,10,opt,name=data,proto3" json:"data,omitempty"`
+	Contract      []*Transaction_Contract `protobuf:"bytes,11,rep,name=contract,proto3" json:"contract,omitempty"`
+	Scripts       []byte                  `protobuf:"bytes,12,opt,name=scripts,proto3" json:"scripts,omitempty"`
+	Timestamp     int64                   `protobuf:"varint,14,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	FeeLimit      int64                   `protobuf:"varint,18,opt,name=fee_limit,json=feeLimit,proto3" json:"fee_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionRaw) Reset() {
+	*x = TransactionRaw{}
+	mi := &file_core_Tron_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRaw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRaw) ProtoMessage() {}
+
+func (x *TransactionRaw) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRaw.ProtoReflect.Descriptor instead.
+func (*TransactionRaw) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *TransactionRaw) GetRefBlockBytes() []byte {
+	if x != nil {
+		return x.RefBlockBytes
+	}
+	return nil
+}
+
+func (x *TransactionRaw) GetRefBlockNum() int64 {
+	if x != nil {
+		return x.RefBlockNum
+	}
+	return 0
+}
+
+func (x *TransactionRaw) GetRefBlockHash() []byte {
+	if x != nil {
+		return x.RefBlockHash
+	}
+	return nil
+}
+
+func (x *TransactionRaw) GetExpiration() int64 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+func (x *TransactionRaw) GetAuths() []*Authority {
+	if x != nil {
+		return x.Auths
+	}
+	return nil
+}
+
+func (x *TransactionRaw) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TransactionRaw) GetContract() []*Transaction_Contract {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *TransactionRaw) GetScripts() []byte {
+	if x != nil {
+		return x.Scripts
+	}
+	return nil
+}
+
+func (x *TransactionRaw) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TransactionRaw) GetFeeLimit() int64 {
+	if x != nil {
+		return x.FeeLimit
+	}
+	return 0
+}
+
+type TransactionInfo_Log struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics        [][]byte               `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionInfo_Log) Reset() {
+	*x = TransactionInfo_Log{}
+	mi := &file_core_Tron_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionInfo_Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionInfo_Log) ProtoMessage() {}
+
+func (x *TransactionInfo_Log) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionInfo_Log.ProtoReflect.Descriptor instead.
+func (*TransactionInfo_Log) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *TransactionInfo_Log) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *TransactionInfo_Log) GetTopics() [][]byte {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *TransactionInfo_Log) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type BlockHeaderRaw struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp        int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TxTrieRoot       []byte                 `protobuf:"bytes,2,opt,name=txTrieRoot,proto3" json:"txTrieRoot,omitempty"`
+	ParentHash       []byte                 `protobuf:"bytes,3,opt,name=parentHash,proto3" json:"parentHash,omitempty"`
+	Number           int64                  `protobuf:"varint,7,opt,name=number,proto3" json:"number,omitempty"`
+	WitnessId        int64                  `protobuf:"varint,8,opt,name=witness_id,json=witnessId,proto3" json:"witness_id,omitempty"`
+	WitnessAddress   []byte                 `protobuf:"bytes,9,opt,name=witness_address,json=witnessAddress,proto3" json:"witness_address,omitempty"`
+	Version          int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	AccountStateRoot []byte                 `protobuf:"bytes,11,opt,name=accountStateRoot,proto3" json:"accountStateRoot,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BlockHeaderRaw) Reset() {
+	*x = BlockHeaderRaw{}
+	mi := &file_core_Tron_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockHeaderRaw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeaderRaw) ProtoMessage() {}
+
+func (x *BlockHeaderRaw) ProtoReflect() protoreflect.Message {
+	mi := &file_core_Tron_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeaderRaw.ProtoReflect.Descriptor instead.
+func (*BlockHeaderRaw) Descriptor() ([]byte, []int) {
+	return file_core_Tron_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *BlockHeaderRaw) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *BlockHeaderRaw) GetTxTrieRoot() []byte {
+	if x != nil {
+		return x.TxTrieRoot
+	}
+	return nil
+}
+
+func (x *BlockHeaderRaw) GetParentHash() []byte {
+	if x != nil {
+		return x.ParentHash
+	}
+	return nil
+}
+
+func (x *BlockHeaderRaw) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *BlockHeaderRaw) GetWitnessId() int64 {
+	if x != nil {
+		return x.WitnessId
+	}
+	return 0
+}
+
+func (x *BlockHeaderRaw) GetWitnessAddress() []byte {
+	if x != nil {
+		return x.WitnessAddress
+	}
+	return nil
+}
+
+func (x *BlockHeaderRaw) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BlockHeaderRaw) GetAccountStateRoot() []byte {
+	if x != nil {
+		return x.AccountStateRoot
+	}
+	return nil
+}
+
+var File_core_Tron_proto protoreflect.FileDescriptor
+
+const file_core_Tron_proto_rawDesc = "" +
+	"\n" +
+	"\x0fcore/Tron.proto\x12\bprotocol\x1a\x19google/protobuf/any.proto\"9\n" +
+	"\tAccountId\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\fR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\fR\aaddress\"c\n" +
+	"\tauthority\x12-\n" +
+	"\aaccount\x18\x01 \x01(\v2\x13.protocol.AccountIdR\aaccount\x12'\n" +
+	"\x0fpermission_name\x18\x02 \x01(\fR\x0epermissionName\"\x8b\x01\n" +
+	"\aAccount\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\fR\vaccountName\x12)\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x15.protocol.AccountTypeR\x04type\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\fR\aaddress\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\"\xde\x02\n" +
+	"\x0fResourceReceipt\x12!\n" +
+	"\fenergy_usage\x18\x01 \x01(\x03R\venergyUsage\x12\x1d\n" +
+	"\n" +
+	"energy_fee\x18\x02 \x01(\x03R\tenergyFee\x12.\n" +
+	"\x13origin_energy_usage\x18\x03 \x01(\x03R\x11originEnergyUsage\x12,\n" +
+	"\x12energy_usage_total\x18\x04 \x01(\x03R\x10energyUsageTotal\x12\x1b\n" +
+	"\tnet_usage\x18\x05 \x01(\x03R\bnetUsage\x12\x17\n" +
+	"\anet_fee\x18\x06 \x01(\x03R\x06netFee\x12C\n" +
+	"\x06result\x18\a \x01(\x0e2+.protocol.Transaction.Result.contractResultR\x06result\x120\n" +
+	"\x14energy_penalty_total\x18\b \x01(\x03R\x12energyPenaltyTotal\"\xf4\x12\n" +
+	"\vTransaction\x124\n" +
+	"\braw_data\x18\x01 \x01(\v2\x19.protocol.Transaction.rawR\arawData\x12\x1c\n" +
+	"\tsignature\x18\x02 \x03(\fR\tsignature\x12.\n" +
+	"\x03ret\x18\x05 \x03(\v2\x1c.protocol.Transaction.ResultR\x03ret\x1a\xef\n" +
+	"\n" +
+	"\bContract\x12?\n" +
+	"\x04type\x18\x01 \x01(\x0e2+.protocol.Transaction.Contract.ContractTypeR\x04type\x122\n" +
+	"\tparameter\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\tparameter\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\fR\bprovider\x12\"\n" +
+	"\fContractName\x18\x04 \x01(\fR\fContractName\x12#\n" +
+	"\rPermission_id\x18\x05 \x01(\x05R\fPermissionId\"\x88\t\n" +
+	"\fContractType\x12\x19\n" +
+	"\x15AccountCreateContract\x10\x00\x12\x14\n" +
+	"\x10TransferContract\x10\x01\x12\x19\n" +
+	"\x15TransferAssetContract\x10\x02\x12\x15\n" +
+	"\x11VoteAssetContract\x10\x03\x12\x17\n" +
+	"\x13VoteWitnessContract\x10\x04\x12\x19\n" +
+	"\x15WitnessCreateContract\x10\x05\x12\x16\n" +
+	"\x12AssetIssueContract\x10\x06\x12\x19\n" +
+	"\x15WitnessUpdateContract\x10\b\x12!\n" +
+	"\x1dParticipateAssetIssueContract\x10\t\x12\x19\n" +
+	"\x15AccountUpdateContract\x10\n" +
+	"\x12\x19\n" +
+	"\x15FreezeBalanceContract\x10\v\x12\x1b\n" +
+	"\x17UnfreezeBalanceContract\x10\f\x12\x1b\n" +
+	"\x17WithdrawBalanceContract\x10\r\x12\x19\n" +
+	"\x15UnfreezeAssetContract\x10\x0e\x12\x17\n" +
+	"\x13UpdateAssetContract\x10\x0f\x12\x1a\n" +
+	"\x16ProposalCreateContract\x10\x10\x12\x1b\n" +
+	"\x17ProposalApproveContract\x10\x11\x12\x1a\n" +
+	"\x16ProposalDeleteContract\x10\x12\x12\x18\n" +
+	"\x14SetAccountIdContract\x10\x13\x12\x12\n" +
+	"\x0eCustomContract\x10\x14\x12\x17\n" +
+	"\x13CreateSmartContract\x10\x1e\x12\x18\n" +
+	"\x14TriggerSmartContract\x10\x1f\x12\x0f\n" +
+	"\vGetContract\x10 \x12\x19\n" +
+	"\x15UpdateSettingContract\x10!\x12\x1a\n" +
+	"\x16ExchangeCreateContract\x10)\x12\x1a\n" +
+	"\x16ExchangeInjectContract\x10*\x12\x1c\n" +
+	"\x18ExchangeWithdrawContract\x10+\x12\x1f\n" +
+	"\x1bExchangeTransactionContract\x10,\x12\x1d\n" +
+	"\x19UpdateEnergyLimitContract\x10-\x12#\n" +
+	"\x1fAccountPermissionUpdateContract\x10.\x12\x14\n" +
+	"\x10ClearABIContract\x100\x12\x1b\n" +
+	"\x17UpdateBrokerageContract\x101\x12\x1c\n" +
+	"\x18ShieldedTransferContract\x103\x12\x1b\n" +
+	"\x17MarketSellAssetContract\x104\x12\x1d\n" +
+	"\x19MarketCancelOrderContract\x105\x12\x1b\n" +
+	"\x17FreezeBalanceV2Contract\x106\x12\x1d\n" +
+	"\x19UnfreezeBalanceV2Contract\x107\x12\"\n" +
+	"\x1eWithdrawExpireUnfreezeContract\x108\x12\x1c\n" +
+	"\x18DelegateResourceContract\x109\x12\x1e\n" +
+	"\x1aUnDelegateResourceContract\x10:\x12\x1f\n" +
+	"\x1bCancelAllUnfreezeV2Contract\x10;\x1a\x84\x04\n" +
+	"\x06Result\x12\x10\n" +
+	"\x03fee\x18\x01 \x01(\x03R\x03fee\x123\n" +
+	"\x03ret\x18\x02 \x01(\x0e2!.protocol.Transaction.Result.codeR\x03ret\x12M\n" +
+	"\vcontractRet\x18\x03 \x01(\x0e2+.protocol.Transaction.Result.contractResultR\vcontractRet\"\x1e\n" +
+	"\x04code\x12\n" +
+	"\n" +
+	"\x06SUCESS\x10\x00\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x01\"\xc3\x02\n" +
+	"\x0econtractResult\x12\v\n" +
+	"\aDEFAULT\x10\x00\x12\v\n" +
+	"\aSUCCESS\x10\x01\x12\n" +
+	"\n" +
+	"\x06REVERT\x10\x02\x12\x18\n" +
+	"\x14BAD_JUMP_DESTINATION\x10\x03\x12\x11\n" +
+	"\rOUT_OF_MEMORY\x10\x04\x12\x18\n" +
+	"\x14PRECOMPILED_CONTRACT\x10\x05\x12\x13\n" +
+	"\x0fSTACK_TOO_SMALL\x10\x06\x12\x13\n" +
+	"\x0fSTACK_TOO_LARGE\x10\a\x12\x15\n" +
+	"\x11ILLEGAL_OPERATION\x10\b\x12\x12\n" +
+	"\x0eSTACK_OVERFLOW\x10\t\x12\x11\n" +
+	"\rOUT_OF_ENERGY\x10\n" +
+	"\x12\x0f\n" +
+	"\vOUT_OF_TIME\x10\v\x12\x17\n" +
+	"\x13JVM_STACK_OVER_FLOW\x10\f\x12\v\n" +
+	"\aUNKNOWN\x10\r\x12\x13\n" +
+	"\x0fTRANSFER_FAILED\x10\x0e\x12\x10\n" +
+	"\fINVALID_CODE\x10\x0f\x1a\xe7\x02\n" +
+	"\x03raw\x12&\n" +
+	"\x0fref_block_bytes\x18\x01 \x01(\fR\rrefBlockBytes\x12\"\n" +
+	"\rref_block_num\x18\x03 \x01(\x03R\vrefBlockNum\x12$\n" +
+	"\x0eref_block_hash\x18\x04 \x01(\fR\frefBlockHash\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\b \x01(\x03R\n" +
+	"expiration\x12)\n" +
+	"\x05auths\x18\t \x03(\v2\x13.protocol.authorityR\x05auths\x12\x12\n" +
+	"\x04data\x18\n" +
+	" \x01(\fR\x04data\x12:\n" +
+	"\bcontract\x18\v \x03(\v2\x1e.protocol.Transaction.ContractR\bcontract\x12\x18\n" +
+	"\ascripts\x18\f \x01(\fR\ascripts\x12\x1c\n" +
+	"\ttimestamp\x18\x0e \x01(\x03R\ttimestamp\x12\x1b\n" +
+	"\tfee_limit\x18\x12 \x01(\x03R\bfeeLimit\"\xfb\x03\n" +
+	"\x0fTransactionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x10\n" +
+	"\x03fee\x18\x02 \x01(\x03R\x03fee\x12 \n" +
+	"\vblockNumber\x18\x03 \x01(\x03R\vblockNumber\x12&\n" +
+	"\x0eblockTimeStamp\x18\x04 \x01(\x03R\x0eblockTimeStamp\x12&\n" +
+	"\x0econtractResult\x18\x05 \x03(\fR\x0econtractResult\x12)\n" +
+	"\x10contract_address\x18\x06 \x01(\fR\x0fcontractAddress\x123\n" +
+	"\areceipt\x18\a \x01(\v2\x19.protocol.ResourceReceiptR\areceipt\x12/\n" +
+	"\x03log\x18\b \x03(\v2\x1d.protocol.TransactionInfo.LogR\x03log\x126\n" +
+	"\x06result\x18\t \x01(\x0e2\x1e.protocol.TransactionInfo.codeR\x06result\x12\x1e\n" +
+	"\n" +
+	"resMessage\x18\n" +
+	" \x01(\fR\n" +
+	"resMessage\x1aK\n" +
+	"\x03Log\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x16\n" +
+	"\x06topics\x18\x02 \x03(\fR\x06topics\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x1e\n" +
+	"\x04code\x12\n" +
+	"\n" +
+	"\x06SUCESS\x10\x00\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x01\"\xfc\x02\n" +
+	"\vBlockHeader\x124\n" +
+	"\braw_data\x18\x01 \x01(\v2\x19.protocol.BlockHeader.rawR\arawData\x12+\n" +
+	"\x11witness_signature\x18\x02 \x01(\fR\x10witnessSignature\x1a\x89\x02\n" +
+	"\x03raw\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x1e\n" +
+	"\n" +
+	"txTrieRoot\x18\x02 \x01(\fR\n" +
+	"txTrieRoot\x12\x1e\n" +
+	"\n" +
+	"parentHash\x18\x03 \x01(\fR\n" +
+	"parentHash\x12\x16\n" +
+	"\x06number\x18\a \x01(\x03R\x06number\x12\x1d\n" +
+	"\n" +
+	"witness_id\x18\b \x01(\x03R\twitnessId\x12'\n" +
+	"\x0fwitness_address\x18\t \x01(\fR\x0ewitnessAddress\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x05R\aversion\x12*\n" +
+	"\x10accountStateRoot\x18\v \x01(\fR\x10accountStateRoot*7\n" +
+	"\vAccountType\x12\n" +
+	"\n" +
+	"\x06Normal\x10\x00\x12\x0e\n" +
+	"\n" +
+	"AssetIssue\x10\x01\x12\f\n" +
+	"\bContract\x10\x02B4Z2github.com/joshuayildiz/wallet/chain/trongrpc/coreb\x06proto3"
+
+var (
+	file_core_Tron_proto_rawDescOnce sync.Once
+	file_core_Tron_proto_rawDescData []byte
+)
+
+func file_core_Tron_proto_rawDescGZIP() []byte {
+	file_core_Tron_proto_rawDescOnce.Do(func() {
+		file_core_Tron_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_Tron_proto_rawDesc), len(file_core_Tron_proto_rawDesc)))
+	})
+	return file_core_Tron_proto_rawDescData
+}
+
+var file_core_Tron_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_core_Tron_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_core_Tron_proto_goTypes = []any{
+	(AccountType)(0),                       // 0: protocol.AccountType
+	(Transaction_Contract_ContractType)(0), // 1: protocol.Transaction.Contract.ContractType
+	(Transaction_ResultCode)(0),            // 2: protocol.Transaction.Result.code
+	(Transaction_ResultContractResult)(0),  // 3: protocol.Transaction.Result.contractResult
+	(TransactionInfoCode)(0),               // 4: protocol.TransactionInfo.code
+	(*AccountId)(nil),                      // 5: protocol.AccountId
+	(*Authority)(nil),                      // 6: protocol.authority
+	(*Account)(nil),                        // 7: protocol.Account
+	(*ResourceReceipt)(nil),                // 8: protocol.ResourceReceipt
+	(*Transaction)(nil),                    // 9: protocol.Transaction
+	(*TransactionInfo)(nil),                // 10: protocol.TransactionInfo
+	(*BlockHeader)(nil),                    // 11: protocol.BlockHeader
+	(*Transaction_Contract)(nil),           // 12: protocol.Transaction.Contract
+	(*Transaction_Result)(nil),             // 13: protocol.Transaction.Result
+	(*TransactionRaw)(nil),                 // 14: protocol.Transaction.raw
+	(*TransactionInfo_Log)(nil),            // 15: protocol.TransactionInfo.Log
+	(*BlockHeaderRaw)(nil),                 // 16: protocol.BlockHeader.raw
+	(*anypb.Any)(nil),                      // 17: google.protobuf.Any
+}
+var file_core_Tron_proto_depIdxs = []int32{
+	5,  // 0: protocol.authority.account:type_name -> protocol.AccountId
+	0,  // 1: protocol.Account.type:type_name -> protocol.AccountType
+	3,  // 2: protocol.ResourceReceipt.result:type_name -> protocol.Transaction.Result.contractResult
+	14, // 3: protocol.Transaction.raw_data:type_name -> protocol.Transaction.raw
+	13, // 4: protocol.Transaction.ret:type_name -> protocol.Transaction.Result
+	8,  // 5: protocol.TransactionInfo.receipt:type_name -> protocol.ResourceReceipt
+	15, // 6: protocol.TransactionInfo.log:type_name -> protocol.TransactionInfo.Log
+	4,  // 7: protocol.TransactionInfo.result:type_name -> protocol.TransactionInfo.code
+	16, // 8: protocol.BlockHeader.raw_data:type_name -> protocol.BlockHeader.raw
+	1,  // 9: protocol.Transaction.Contract.type:type_name -> protocol.Transaction.Contract.ContractType
+	17, // 10: protocol.Transaction.Contract.parameter:type_name -> google.protobuf.Any
+	2,  // 11: protocol.Transaction.Result.ret:type_name -> protocol.Transaction.Result.code
+	3,  // 12: protocol.Transaction.Result.contractRet:type_name -> protocol.Transaction.Result.contractResult
+	6,  // 13: protocol.Transaction.raw.auths:type_name -> protocol.authority
+	12, // 14: protocol.Transaction.raw.contract:type_name -> protocol.Transaction.Contract
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_core_Tron_proto_init() }
+func file_core_Tron_proto_init() {
+	if File_core_Tron_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_Tron_proto_rawDesc), len(file_core_Tron_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_Tron_proto_goTypes,
+		DependencyIndexes: file_core_Tron_proto_depIdxs,
+		EnumInfos:         file_core_Tron_proto_enumTypes,
+		MessageInfos:      file_core_Tron_proto_msgTypes,
+	}.Build()
+	File_core_Tron_proto = out.File
+	file_core_Tron_proto_goTypes = nil
+	file_core_Tron_proto_depIdxs = nil
+}
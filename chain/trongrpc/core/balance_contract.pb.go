@@ -0,0 +1,144 @@
+// Subset of core/contract/balance_contract.proto of
+// github.com/tronprotocol/protocol.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: core/contract/balance_contract.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransferContract struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerAddress  []byte                 `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	ToAddress     []byte                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferContract) Reset() {
+	*x = TransferContract{}
+	mi := &file_core_contract_balance_contract_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferContract) ProtoMessage() {}
+
+func (x *TransferContract) ProtoReflect() protoreflect.Message {
+	mi := &file_core_contract_balance_contract_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferContract.ProtoReflect.Descriptor instead.
+func (*TransferContract) Descriptor() ([]byte, []int) {
+	return file_core_contract_balance_contract_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferContract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *TransferContract) GetToAddress() []byte {
+	if x != nil {
+		return x.ToAddress
+	}
+	return nil
+}
+
+func (x *TransferContract) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+var File_core_contract_balance_contract_proto protoreflect.FileDescriptor
+
+const file_core_contract_balance_contract_proto_rawDesc = "" +
+	"\n" +
+	"$core/contract/balance_contract.proto\x12\bprotocol\"n\n" +
+	"\x10TransferContract\x12#\n" +
+	"\rowner_address\x18\x01 \x01(\fR\fownerAddress\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x02 \x01(\fR\ttoAddress\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amountB4Z2github.com/joshuayildiz/wallet/chain/trongrpc/coreb\x06proto3"
+
+var (
+	file_core_contract_balance_contract_proto_rawDescOnce sync.Once
+	file_core_contract_balance_contract_proto_rawDescData []byte
+)
+
+func file_core_contract_balance_contract_proto_rawDescGZIP() []byte {
+	file_core_contract_balance_contract_proto_rawDescOnce.Do(func() {
+		file_core_contract_balance_contract_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_contract_balance_contract_proto_rawDesc), len(file_core_contract_balance_contract_proto_rawDesc)))
+	})
+	return file_core_contract_balance_contract_proto_rawDescData
+}
+
+var file_core_contract_balance_contract_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_contract_balance_contract_proto_goTypes = []any{
+	(*TransferContract)(nil), // 0: protocol.TransferContract
+}
+var file_core_contract_balance_contract_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_core_contract_balance_contract_proto_init() }
+func file_core_contract_balance_contract_proto_init() {
+	if File_core_contract_balance_contract_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_contract_balance_contract_proto_rawDesc), len(file_core_contract_balance_contract_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_contract_balance_contract_proto_goTypes,
+		DependencyIndexes: file_core_contract_balance_contract_proto_depIdxs,
+		MessageInfos:      file_core_contract_balance_contract_proto_msgTypes,
+	}.Build()
+	File_core_contract_balance_contract_proto = out.File
+	file_core_contract_balance_contract_proto_goTypes = nil
+	file_core_contract_balance_contract_proto_depIdxs = nil
+}
@@ -0,0 +1,171 @@
+// Subset of core/contract/smart_contract.proto of
+// github.com/tronprotocol/protocol.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: core/contract/smart_contract.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TriggerSmartContract struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OwnerAddress    []byte                 `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	ContractAddress []byte                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	CallValue       int64                  `protobuf:"varint,3,opt,name=call_value,json=callValue,proto3" json:"call_value,omitempty"`
+	Data            []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	CallTokenValue  int64                  `protobuf:"varint,5,opt,name=call_token_value,json=callTokenValue,proto3" json:"call_token_value,omitempty"`
+	TokenId         int64                  `protobuf:"varint,6,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TriggerSmartContract) Reset() {
+	*x = TriggerSmartContract{}
+	mi := &file_core_contract_smart_contract_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSmartContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSmartContract) ProtoMessage() {}
+
+func (x *TriggerSmartContract) ProtoReflect() protoreflect.Message {
+	mi := &file_core_contract_smart_contract_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSmartContract.ProtoReflect.Descriptor instead.
+func (*TriggerSmartContract) Descriptor() ([]byte, []int) {
+	return file_core_contract_smart_contract_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TriggerSmartContract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *TriggerSmartContract) GetContractAddress() []byte {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return nil
+}
+
+func (x *TriggerSmartContract) GetCallValue() int64 {
+	if x != nil {
+		return x.CallValue
+	}
+	return 0
+}
+
+func (x *TriggerSmartContract) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TriggerSmartContract) GetCallTokenValue() int64 {
+	if x != nil {
+		return x.CallTokenValue
+	}
+	return 0
+}
+
+func (x *TriggerSmartContract) GetTokenId() int64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+var File_core_contract_smart_contract_proto protoreflect.FileDescriptor
+
+const file_core_contract_smart_contract_proto_rawDesc = "" +
+	"\n" +
+	"\"core/contract/smart_contract.proto\x12\bprotocol\"\xde\x01\n" +
+	"\x14TriggerSmartContract\x12#\n" +
+	"\rowner_address\x18\x01 \x01(\fR\fownerAddress\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\fR\x0fcontractAddress\x12\x1d\n" +
+	"\n" +
+	"call_value\x18\x03 \x01(\x03R\tcallValue\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12(\n" +
+	"\x10call_token_value\x18\x05 \x01(\x03R\x0ecallTokenValue\x12\x19\n" +
+	"\btoken_id\x18\x06 \x01(\x03R\atokenIdB4Z2github.com/joshuayildiz/wallet/chain/trongrpc/coreb\x06proto3"
+
+var (
+	file_core_contract_smart_contract_proto_rawDescOnce sync.Once
+	file_core_contract_smart_contract_proto_rawDescData []byte
+)
+
+func file_core_contract_smart_contract_proto_rawDescGZIP() []byte {
+	file_core_contract_smart_contract_proto_rawDescOnce.Do(func() {
+		file_core_contract_smart_contract_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_contract_smart_contract_proto_rawDesc), len(file_core_contract_smart_contract_proto_rawDesc)))
+	})
+	return file_core_contract_smart_contract_proto_rawDescData
+}
+
+var file_core_contract_smart_contract_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_contract_smart_contract_proto_goTypes = []any{
+	(*TriggerSmartContract)(nil), // 0: protocol.TriggerSmartContract
+}
+var file_core_contract_smart_contract_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_core_contract_smart_contract_proto_init() }
+func file_core_contract_smart_contract_proto_init() {
+	if File_core_contract_smart_contract_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_contract_smart_contract_proto_rawDesc), len(file_core_contract_smart_contract_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_contract_smart_contract_proto_goTypes,
+		DependencyIndexes: file_core_contract_smart_contract_proto_depIdxs,
+		MessageInfos:      file_core_contract_smart_contract_proto_msgTypes,
+	}.Build()
+	File_core_contract_smart_contract_proto = out.File
+	file_core_contract_smart_contract_proto_goTypes = nil
+	file_core_contract_smart_contract_proto_depIdxs = nil
+}
@@ -0,0 +1,306 @@
+package trongrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/api"
+	"github.com/joshuayildiz/wallet/chain/trongrpc/core"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"google.golang.org/protobuf/proto"
+)
+
+func (r *Client) Network() chain.Network {
+	return r.Net
+}
+
+func (r *Client) Balance(ctx context.Context, addr string) (uint, error) {
+	a, err := tronaddr.Parse(addr)
+	if err != nil {
+		return 0, err
+	}
+
+	account, err := r.fullnode.GetAccount(ctx, &core.Account{Address: a[:]})
+	if err != nil {
+		return 0, fmt.Errorf("fetching balance: %w", err)
+	}
+	return uint(account.GetBalance()), nil
+}
+
+// Now returns the latest solidified block.
+func (r *Client) Now(ctx context.Context) (*trongrid.Block, error) {
+	ext, err := r.solidity.GetNowBlock2(ctx, &api.EmptyMessage{})
+	return nowBlock(ext, err)
+}
+
+// Head returns the latest block of the fullnode, which is not solidified yet
+// and can still be reorganized away.
+func (r *Client) Head(ctx context.Context) (*trongrid.Block, error) {
+	ext, err := r.fullnode.GetNowBlock2(ctx, &api.EmptyMessage{})
+	return nowBlock(ext, err)
+}
+
+func nowBlock(ext *api.BlockExtention, err error) (*trongrid.Block, error) {
+	if err != nil {
+		return nil, fmt.Errorf("fetching now block: %w", err)
+	}
+	b, err := convertBlock(ext)
+	if err != nil {
+		return nil, fmt.Errorf("decoding now block: %w", err)
+	}
+	return b, nil
+}
+
+func (r *Client) BlockByNum(ctx context.Context, num uint) (*trongrid.Block, error) {
+	ext, err := r.solidity.GetBlockByNum2(ctx, &api.NumberMessage{Num: int64(num)})
+	return blockByNum(num, ext, err)
+}
+
+// HeadBlockByNum is BlockByNum for blocks that are not solidified yet.
+func (r *Client) HeadBlockByNum(ctx context.Context, num uint) (*trongrid.Block, error) {
+	ext, err := r.fullnode.GetBlockByNum2(ctx, &api.NumberMessage{Num: int64(num)})
+	return blockByNum(num, ext, err)
+}
+
+func blockByNum(num uint, ext *api.BlockExtention, err error) (*trongrid.Block, error) {
+	if err != nil {
+		return nil, fmt.Errorf("fetching block %d: %w", num, err)
+	}
+	b, err := convertBlock(ext)
+	if err != nil {
+		return nil, fmt.Errorf("decoding block %d: %w", num, err)
+	}
+	return b, nil
+}
+
+func (r *Client) TxInfoByBlockNum(ctx context.Context, num uint) ([]trongrid.TxInfo, error) {
+	list, err := r.solidity.GetTransactionInfoByBlockNum(ctx, &api.NumberMessage{Num: int64(num)})
+	return txInfoByBlockNum(num, list, err)
+}
+
+// HeadTxInfoByBlockNum is TxInfoByBlockNum for blocks that are not solidified
+// yet.
+func (r *Client) HeadTxInfoByBlockNum(ctx context.Context, num uint) ([]trongrid.TxInfo, error) {
+	list, err := r.fullnode.GetTransactionInfoByBlockNum(ctx, &api.NumberMessage{Num: int64(num)})
+	return txInfoByBlockNum(num, list, err)
+}
+
+func txInfoByBlockNum(num uint, list *api.TransactionInfoList, err error) ([]trongrid.TxInfo, error) {
+	if err != nil {
+		return nil, fmt.Errorf("fetching tx info by block num %d: %w", num, err)
+	}
+	infos := []trongrid.TxInfo{}
+	for _, info := range list.GetTransactionInfo() {
+		infos = append(infos, *convertTxInfo(info))
+	}
+	return infos, nil
+}
+
+func (r *Client) TxInfoByID(ctx context.Context, id string) (*trongrid.TxInfo, error) {
+	b, err := hex.DecodeString(id)
+	if err != nil {
+		return nil, fmt.Errorf("decoding tx id %s: %w", id, err)
+	}
+
+	info, err := r.solidity.GetTransactionInfoById(ctx, &api.BytesMessage{Value: b})
+	if err != nil {
+		return nil, fmt.Errorf("fetching tx by id %s: %w", id, err)
+	}
+	return convertTxInfo(info), nil
+}
+
+func (r *Client) CreateTx(ctx context.Context, from, to string, amt uint) (*trongrid.Tx, error) {
+	return r.CreateTxWithPermission(ctx, from, to, amt, 0)
+}
+
+// CreateTxWithPermission creates a trx transfer that has to be signed by the
+// keys of the given permission of the sender, 0 being the owner permission.
+func (r *Client) CreateTxWithPermission(ctx context.Context, from, to string, amt uint, permissionID int) (*trongrid.Tx, error) {
+	fromAddr, err := tronaddr.Parse(from)
+	if err != nil {
+		return nil, err
+	}
+	toAddr, err := tronaddr.Parse(to)
+	if err != nil {
+		return nil, err
+	}
+
+	ext, err := r.fullnode.CreateTransaction2(ctx, &core.TransferContract{
+		OwnerAddress: fromAddr[:],
+		ToAddress:    toAddr[:],
+		Amount:       int64(amt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating tx: %w", err)
+	}
+
+	tx, err := createdTx(ext, 0, permissionID)
+	if err != nil {
+		return nil, fmt.Errorf("creating tx: %w", err)
+	}
+	return tx, nil
+}
+
+// TriggerContract creates an unsigned transaction calling a contract.
+func (r *Client) TriggerContract(ctx context.Context, t trongrid.Trigger) (*trongrid.Tx, error) {
+	in, err := triggerSmartContract(t.From, t.Contract, t.Method, t.Args, t.CallValue, t.TokenID, t.TokenValue)
+	if err != nil {
+		return nil, fmt.Errorf("triggering %s on %s: %w", t.Method.Name, t.Contract, err)
+	}
+
+	ext, err := r.fullnode.TriggerContract(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("triggering %s on %s: %w", t.Method.Name, t.Contract, err)
+	}
+
+	tx, err := createdTx(ext, t.FeeLimit, t.PermissionID)
+	if err != nil {
+		return nil, fmt.Errorf("triggering %s on %s: %w", t.Method.Name, t.Contract, err)
+	}
+	return tx, nil
+}
+
+// createdTx converts a newly created transaction and sets what the api does
+// not take as arguments.
+func createdTx(ext *api.TransactionExtention, feeLimit uint, permissionID int) (*trongrid.Tx, error) {
+	if !ext.GetResult().GetResult() {
+		return nil, resultError(ext.GetResult())
+	}
+
+	rewriteRaw(ext.GetTransaction(), feeLimit, permissionID)
+	tx, err := convertTx(ext.GetTransaction())
+	if err != nil {
+		return nil, fmt.Errorf("decoding created tx: %w", err)
+	}
+	return &tx, nil
+}
+
+// CallConstant runs a read-only call against the solidified state as from and
+// returns the decoded outputs of method.
+func (r *Client) CallConstant(ctx context.Context, from, contract string, method *abi.Method, args ...any) ([]any, error) {
+	in, err := triggerSmartContract(from, contract, method, args, 0, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+
+	ext, err := r.solidity.TriggerConstantContract(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+	if !ext.GetResult().GetResult() {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, resultError(ext.GetResult()))
+	}
+	if len(ext.GetConstantResult()) == 0 {
+		return nil, fmt.Errorf("calling %s on %s: constantresult was empty", method.Name, contract)
+	}
+
+	cRes := ext.GetConstantResult()[0]
+	ret := ext.GetTransaction().GetRet()
+	if len(ret) > 0 && ret[0].GetRet() == core.Transaction_Result_FAILED {
+		reason, _ := abi.DecodeRevert(cRes, nil)
+		err = &trongrid.RevertError{Code: "REVERT", Reason: reason, Data: cRes}
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+
+	values, err := method.Unpack(cRes)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s result of %s: %w", method.Name, contract, err)
+	}
+	return values, nil
+}
+
+func triggerSmartContract(from, contract string, method *abi.Method, args []any, callValue, tokenID, tokenValue uint) (*core.TriggerSmartContract, error) {
+	var fromAddr []byte
+	if from != "" {
+		addr, err := tronaddr.Parse(from)
+		if err != nil {
+			return nil, err
+		}
+		fromAddr = addr[:]
+	}
+	contractAddr, err := tronaddr.Parse(contract)
+	if err != nil {
+		return nil, err
+	}
+	data, err := method.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.TriggerSmartContract{
+		OwnerAddress:    fromAddr,
+		ContractAddress: contractAddr[:],
+		CallValue:       int64(callValue),
+		Data:            data,
+		CallTokenValue:  int64(tokenValue),
+		TokenId:         int64(tokenID),
+	}, nil
+}
+
+func (r *Client) Broadcast(ctx context.Context, tx trongrid.Tx) (string, error) {
+	in, err := signedTx(tx)
+	if err != nil {
+		return "", fmt.Errorf("broadcasting tx: %w", err)
+	}
+
+	res, err := r.fullnode.BroadcastTransaction(ctx, in)
+	if err != nil {
+		return "", fmt.Errorf("broadcasting tx: %w", err)
+	}
+	if !res.GetResult() {
+		return "", &trongrid.BroadcastError{Code: res.GetCode().String(), Message: string(res.GetMessage())}
+	}
+	return tx.TxID, nil
+}
+
+// signedTx decodes a signed transaction for broadcasting. The raw data has
+// to encode back to the same bytes, otherwise the signatures would not match.
+func signedTx(tx trongrid.Tx) (*core.Transaction, error) {
+	if tx.RawDataHex == "" {
+		return nil, fmt.Errorf("tx %s has no raw data", tx.TxID)
+	}
+	b, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil {
+		return nil, fmt.Errorf("decoding raw data of tx %s: %w", tx.TxID, err)
+	}
+
+	var raw core.TransactionRaw
+	err = proto.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("decoding raw data of tx %s: %w", tx.TxID, err)
+	}
+	encoded, err := proto.Marshal(&raw)
+	if err != nil || !bytes.Equal(encoded, b) {
+		return nil, fmt.Errorf("raw data of tx %s is not canonically encoded", tx.TxID)
+	}
+
+	in := &core.Transaction{RawData: &raw}
+	for _, sig := range tx.Signature {
+		b, err := hex.DecodeString(sig)
+		if err != nil {
+			return nil, fmt.Errorf("decoding signature of tx %s: %w", tx.TxID, err)
+		}
+		in.Signature = append(in.Signature, b)
+	}
+	return in, nil
+}
+
+// resultError builds an error from a failed Return like trongrid.Client does.
+func resultError(res *api.Return) error {
+	code, msg := res.GetCode().String(), string(res.GetMessage())
+	if res.GetCode() == api.Return_CONTRACT_VALIDATE_ERROR || res.GetCode() == api.Return_CONTRACT_EXE_ERROR {
+		return &trongrid.RevertError{Code: code, Reason: msg}
+	}
+	if msg == "" {
+		return errors.New(code)
+	}
+	return fmt.Errorf("%s: %s", code, msg)
+}
+
+var _ trongrid.Backend = (*Client)(nil)
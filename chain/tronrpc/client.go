@@ -0,0 +1,119 @@
+// Package tronrpc talks to the ethereum compatible json-rpc api of java-tron,
+// e.g. https://api.trongrid.io/jsonrpc.
+package tronrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/joshuayildiz/wallet/chain"
+)
+
+type Client struct {
+	Net    chain.Network
+	apikey string
+	url    string
+	client *http.Client
+	id     atomic.Uint64
+}
+
+type ClientOption func(*Client)
+
+// WithURL makes the client talk to a different endpoint than trongrid's,
+// e.g. a self-hosted node on port 50545.
+func WithURL(url string) ClientOption {
+	return func(r *Client) {
+		r.url = url
+	}
+}
+
+func New(net chain.Network, apikey string, opts ...ClientOption) *Client {
+	retryableClient := retryablehttp.NewClient()
+	retryableClient.RetryMax = 3
+	retryableClient.Logger = nil
+	self := &Client{
+		Net:    net,
+		apikey: apikey,
+		client: retryableClient.StandardClient(),
+	}
+	switch net {
+	case chain.Mainnet:
+		self.url = "https://api.trongrid.io/jsonrpc"
+	case chain.Testnet:
+		self.url = "https://api.shasta.trongrid.io/jsonrpc"
+	}
+	for _, opt := range opts {
+		opt(self)
+	}
+	return self
+}
+
+// Error is an error object returned by the node.
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (r *Error) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", r.Code, r.Message)
+}
+
+// call invokes method and decodes the result into out.
+func (r *Client) call(ctx context.Context, method string, params []any, out any) error {
+	if params == nil {
+		params = []any{}
+	}
+	body := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      r.id.Add(1),
+		"method":  method,
+		"params":  params,
+	}
+	bodyBytes, _ := json.Marshal(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("TRON-PRO-API-KEY", r.apikey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling %s: %s", method, resp.Status)
+	}
+
+	var data struct {
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", method, err)
+	}
+	if data.Error != nil {
+		return fmt.Errorf("calling %s: %w", method, data.Error)
+	}
+
+	err = json.Unmarshal(data.Result, out)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", method, err)
+	}
+	return nil
+}
+
+func trimHex(s string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+}
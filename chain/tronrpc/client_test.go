@@ -0,0 +1,195 @@
+package tronrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	alice = "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC"
+	usdt  = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+)
+
+// fakeNode answers json-rpc calls with handlers by method.
+func fakeNode(t *testing.T, handlers map[string]func(params []json.RawMessage) (any, *Error)) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": body.ID}
+		handler, ok := handlers[body.Method]
+		if !ok {
+			resp["error"] = Error{Code: -32601, Message: "method not found"}
+		} else if result, err := handler(body.Params); err != nil {
+			resp["error"] = err
+		} else {
+			resp["result"] = result
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return New(chain.Mainnet, "", WithURL(server.URL))
+}
+
+func evm(addr string) string {
+	return "0x" + hex.EncodeToString(tronaddr.MustParse(addr).EVMBytes())
+}
+
+func TestBalance(t *testing.T) {
+	t.Parallel()
+
+	client := fakeNode(t, map[string]func([]json.RawMessage) (any, *Error){
+		"eth_getBalance": func(params []json.RawMessage) (any, *Error) {
+			assert.JSONEq(t, `"`+evm(alice)+`"`, string(params[0]))
+			return "0x1e8480", nil
+		},
+		"eth_blockNumber": func(params []json.RawMessage) (any, *Error) {
+			return "0x3e8", nil
+		},
+	})
+
+	balance, err := client.Balance(context.Background(), alice)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2_000_000), balance)
+
+	num, err := client.BlockNumber(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1000), num)
+}
+
+func TestBlockByNum(t *testing.T) {
+	t.Parallel()
+
+	client := fakeNode(t, map[string]func([]json.RawMessage) (any, *Error){
+		"eth_getBlockByNumber": func(params []json.RawMessage) (any, *Error) {
+			if string(params[0]) == `"0x64"` {
+				return map[string]any{
+					"number":     "0x64",
+					"hash":       "0xaa",
+					"parentHash": "0xbb",
+					"timestamp":  "0x5f5e100",
+					"transactions": []any{map[string]any{
+						"hash":  "0xcc",
+						"from":  evm(alice),
+						"to":    evm(usdt),
+						"value": "0x5",
+					}},
+				}, nil
+			}
+			return nil, nil
+		},
+	})
+
+	b, err := client.BlockByNum(context.Background(), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity(100), b.Number)
+	assert.Equal(t, "0xbb", b.ParentHash)
+	assert.Equal(t, int64(5), b.Transactions[0].Value.Int().Int64())
+
+	_, err = client.BlockByNum(context.Background(), 101)
+	assert.Error(t, err)
+}
+
+func TestLogs(t *testing.T) {
+	t.Parallel()
+
+	client := fakeNode(t, map[string]func([]json.RawMessage) (any, *Error){
+		"eth_getLogs": func(params []json.RawMessage) (any, *Error) {
+			assert.JSONEq(t, `{
+				"fromBlock": "0x64",
+				"toBlock": "0x65",
+				"address": ["`+evm(usdt)+`"],
+				"topics": [["`+transferTopic+`"]]
+			}`, string(params[0]))
+
+			return []any{
+				map[string]any{
+					"address":         strings.ToUpper(evm(usdt)[:2]) + evm(usdt)[2:],
+					"topics":          []string{transferTopic, "0x01", "0x02"},
+					"data":            "0x05",
+					"blockNumber":     "0x64",
+					"blockHash":       "0xaa",
+					"transactionHash": "0xcc",
+					"logIndex":        "0x3",
+				},
+				map[string]any{"removed": true},
+			}, nil
+		},
+	})
+
+	logs, err := client.Logs(context.Background(), 100, 101, []string{usdt}, [][]string{{transferTopic[2:]}})
+	assert.NoError(t, err)
+	assert.Equal(t, []trongrid.Log{{
+		TxLog: trongrid.TxLog{
+			Address: evm(usdt)[2:],
+			Data:    "05",
+			Topics:  []string{transferTopic[2:], "01", "02"},
+		},
+		Block:     100,
+		BlockHash: "aa",
+		TxID:      "cc",
+		LogIndex:  3,
+	}}, logs)
+}
+
+func TestCallConstant(t *testing.T) {
+	t.Parallel()
+
+	balanceOf := abi.MustParseMethod("balanceOf(address) returns (uint256)")
+	var callers []string
+	client := fakeNode(t, map[string]func([]json.RawMessage) (any, *Error){
+		"eth_call": func(params []json.RawMessage) (any, *Error) {
+			var call struct {
+				From string `json:"from"`
+				To   string `json:"to"`
+				Data string `json:"data"`
+			}
+			json.Unmarshal(params[0], &call)
+			assert.Equal(t, evm(usdt), call.To)
+			callers = append(callers, call.From)
+
+			selector := balanceOf.Selector()
+			if call.Data != "0x"+hex.EncodeToString(selector[:])+strings.Repeat("0", 24)+evm(alice)[2:] {
+				// Error(string) "no"
+				data := "0x08c379a0" +
+					"0000000000000000000000000000000000000000000000000000000000000020" +
+					"0000000000000000000000000000000000000000000000000000000000000002" +
+					"6e6f000000000000000000000000000000000000000000000000000000000000"
+				raw, _ := json.Marshal(data)
+				return nil, &Error{Code: 3, Message: "execution reverted", Data: raw}
+			}
+			return "0x" + strings.Repeat("0", 62) + "2a", nil
+		},
+	})
+
+	balance, err := client.TRC20Balance(context.Background(), usdt, alice)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), balance)
+
+	_, err = client.TRC20Balance(context.Background(), usdt, usdt)
+	var revert *trongrid.RevertError
+	assert.ErrorAs(t, err, &revert)
+	assert.Equal(t, "no", revert.Reason)
+
+	// balances are read as the holder
+	assert.Equal(t, []string{evm(alice), evm(usdt)}, callers)
+}
@@ -0,0 +1,229 @@
+package tronrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/tronaddr"
+)
+
+// Balance returns the trx balance of addr in sun.
+func (r *Client) Balance(ctx context.Context, addr string) (uint, error) {
+	evm, err := evmAddr(addr)
+	if err != nil {
+		return 0, err
+	}
+
+	var data BigQuantity
+	err = r.call(ctx, "eth_getBalance", []any{evm, "latest"}, &data)
+	if err != nil {
+		return 0, err
+	}
+
+	balance := data.Int()
+	if !balance.IsUint64() {
+		return 0, fmt.Errorf("balance %s overflows uint", balance)
+	}
+	return uint(balance.Uint64()), nil
+}
+
+// BlockNumber returns the number of the latest block.
+func (r *Client) BlockNumber(ctx context.Context) (uint, error) {
+	var data Quantity
+	err := r.call(ctx, "eth_blockNumber", nil, &data)
+	if err != nil {
+		return 0, err
+	}
+	return uint(data), nil
+}
+
+// Now returns the latest block with its transactions.
+func (r *Client) Now(ctx context.Context) (*Block, error) {
+	return r.block(ctx, "latest")
+}
+
+func (r *Client) BlockByNum(ctx context.Context, num uint) (*Block, error) {
+	return r.block(ctx, Quantity(num).String())
+}
+
+func (r *Client) block(ctx context.Context, tag string) (*Block, error) {
+	var data *Block
+	err := r.call(ctx, "eth_getBlockByNumber", []any{tag, true}, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("block %s not found", tag)
+	}
+	return data, nil
+}
+
+// GetLogs returns the logs matching f.
+func (r *Client) GetLogs(ctx context.Context, f LogFilter) ([]Log, error) {
+	addrs := make([]string, len(f.Addresses))
+	for i, addr := range f.Addresses {
+		evm, err := evmAddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = evm
+	}
+
+	topics := make([]any, len(f.Topics))
+	for i, values := range f.Topics {
+		if len(values) == 0 {
+			continue
+		}
+		prefixed := make([]string, len(values))
+		for j, v := range values {
+			prefixed[j] = "0x" + trimHex(v)
+		}
+		topics[i] = prefixed
+	}
+
+	filter := map[string]any{
+		"fromBlock": Quantity(f.FromBlock).String(),
+		"toBlock":   Quantity(f.ToBlock).String(),
+		"address":   addrs,
+		"topics":    topics,
+	}
+
+	var data []Log
+	err := r.call(ctx, "eth_getLogs", []any{filter}, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Logs implements trongrid.LogSource.
+func (r *Client) Logs(ctx context.Context, from, to uint, contracts []string, topics [][]string) ([]trongrid.Log, error) {
+	logs, err := r.GetLogs(ctx, LogFilter{
+		FromBlock: from,
+		ToBlock:   to,
+		Addresses: contracts,
+		Topics:    topics,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]trongrid.Log, 0, len(logs))
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+
+		topics := make([]string, len(l.Topics))
+		for i, t := range l.Topics {
+			topics[i] = trimHex(t)
+		}
+		out = append(out, trongrid.Log{
+			TxLog: trongrid.TxLog{
+				Address: strings.ToLower(trimHex(l.Address)),
+				Data:    trimHex(l.Data),
+				Topics:  topics,
+			},
+			Block:     uint(l.BlockNumber),
+			BlockHash: trimHex(l.BlockHash),
+			TxID:      trimHex(l.TransactionHash),
+			LogIndex:  int(l.LogIndex),
+		})
+	}
+	return out, nil
+}
+
+// CallConstant runs a read-only call against the latest state as from and
+// returns the decoded outputs of method.
+func (r *Client) CallConstant(ctx context.Context, from, contract string, method *abi.Method, args ...any) ([]any, error) {
+	data, err := method.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+	to, err := evmAddr(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	call := map[string]any{
+		"to":   to,
+		"data": "0x" + hex.EncodeToString(data),
+	}
+	if from != "" {
+		call["from"], err = evmAddr(from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result string
+	err = r.call(ctx, "eth_call", []any{call, "latest"}, &result)
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) && len(rpcErr.Data) > 0 {
+		return nil, revertError(rpcErr)
+	} else if err != nil {
+		return nil, fmt.Errorf("calling %s on %s: %w", method.Name, contract, err)
+	}
+
+	out, err := hex.DecodeString(trimHex(result))
+	if err != nil {
+		return nil, fmt.Errorf("decoding result of %s: %w", method.Name, err)
+	}
+	values, err := method.Unpack(out)
+	if err != nil {
+		return nil, fmt.Errorf("decoding result of %s: %w", method.Name, err)
+	}
+	return values, nil
+}
+
+// revertError turns the revert data of a failed eth_call into the error
+// trongrid.Client returns for reverts.
+func revertError(rpcErr *Error) error {
+	var data string
+	if json.Unmarshal(rpcErr.Data, &data) != nil {
+		return rpcErr
+	}
+	b, err := hex.DecodeString(trimHex(data))
+	if err != nil {
+		return rpcErr
+	}
+
+	reason, _ := abi.DecodeRevert(b, nil)
+	return &trongrid.RevertError{Code: "REVERT", Reason: reason, Data: b}
+}
+
+var trc20BalanceOf = abi.MustParseMethod("balanceOf(address) returns (uint256)")
+
+// TRC20Balance returns the token balance of addr.
+func (r *Client) TRC20Balance(ctx context.Context, contract, addr string) (*big.Int, error) {
+	a, err := tronaddr.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	values, err := r.CallConstant(ctx, addr, contract, trc20BalanceOf, a)
+	if err != nil {
+		return nil, err
+	}
+	return values[0].(*big.Int), nil
+}
+
+// evmAddr converts a base58 or hex address into the 0x prefixed 20 byte form
+// json-rpc expects.
+func evmAddr(addr string) (string, error) {
+	a, err := tronaddr.Parse(addr)
+	if err != nil {
+		if a, err = tronaddr.ParseEVM(addr); err != nil {
+			return "", err
+		}
+	}
+	return "0x" + hex.EncodeToString(a.EVMBytes()), nil
+}
+
+var _ trongrid.LogSource = (*Client)(nil)
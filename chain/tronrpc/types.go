@@ -0,0 +1,100 @@
+package tronrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Quantity is a hex encoded number like 0x1b4.
+type Quantity uint64
+
+func (r *Quantity) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(trimHex(s), 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid quantity %q", s)
+	}
+	*r = Quantity(n)
+	return nil
+}
+
+func (r Quantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r Quantity) String() string {
+	return "0x" + strconv.FormatUint(uint64(r), 16)
+}
+
+// BigQuantity is a Quantity that may not fit 64 bits, e.g. a value in sun.
+type BigQuantity big.Int
+
+func (r *BigQuantity) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	n, ok := new(big.Int).SetString(trimHex(s), 16)
+	if !ok {
+		return fmt.Errorf("invalid quantity %q", s)
+	}
+	*r = BigQuantity(*n)
+	return nil
+}
+
+func (r *BigQuantity) Int() *big.Int {
+	n := big.Int(*r)
+	return &n
+}
+
+// Block as returned by eth_getBlockByNumber. Hashes and addresses keep the
+// 0x prefix of the api, addresses are in the 20 byte evm form.
+type Block struct {
+	Number       Quantity      `json:"number"`
+	Hash         string        `json:"hash"`
+	ParentHash   string        `json:"parentHash"`
+	Timestamp    Quantity      `json:"timestamp"`
+	Miner        string        `json:"miner"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+type Transaction struct {
+	Hash             string      `json:"hash"`
+	BlockNumber      Quantity    `json:"blockNumber"`
+	From             string      `json:"from"`
+	To               string      `json:"to"`
+	Value            BigQuantity `json:"value"`
+	Input            string      `json:"input"`
+	TransactionIndex Quantity    `json:"transactionIndex"`
+}
+
+// Log as returned by eth_getLogs.
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      Quantity `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex Quantity `json:"transactionIndex"`
+	LogIndex         Quantity `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+// LogFilter for eth_getLogs. Addresses are base58 or hex, topics hex with
+// or without 0x.
+type LogFilter struct {
+	FromBlock uint
+	ToBlock   uint
+	Addresses []string
+
+	// Topics[i] lists the accepted values of topic i, empty accepts any.
+	Topics [][]string
+}
@@ -1,6 +1,25 @@
 package cursor
 
+import "github.com/joshuayildiz/wallet/txevent"
+
 type Cursor interface {
 	Curr() uint
 	Adv() error
 }
+
+// Tracked is a cursor that also remembers the ids of recently processed
+// blocks and the events delivered from them. Watchers use it to detect reorgs
+// and revert events of orphaned blocks.
+type Tracked interface {
+	Cursor
+
+	// AdvBlock is Adv for a processed block with id.
+	AdvBlock(id string, events []txevent.E) error
+
+	// Block returns a remembered block, ok is false if num is too old or was
+	// not processed yet.
+	Block(num uint) (id string, events []txevent.E, ok bool)
+
+	// Rewind moves the cursor back to num and forgets blocks from num on.
+	Rewind(num uint) error
+}
@@ -0,0 +1,141 @@
+package cursor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/joshuayildiz/wallet/internal/atomicfile"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// File is a Tracked cursor persisted as a json file.
+type File struct {
+	path  string
+	depth int
+
+	mu    sync.Mutex
+	state fileState
+}
+
+type fileState struct {
+	Curr   uint        `json:"curr"`
+	Blocks []fileBlock `json:"blocks"`
+}
+
+type fileBlock struct {
+	Num    uint        `json:"num"`
+	ID     string      `json:"id"`
+	Events []txevent.E `json:"events"`
+}
+
+// NewFile loads the cursor at path or starts at start if the file does not
+// exist. The last depth blocks are remembered, which bounds how deep reorgs
+// can be undone, so depth has to be at least 1.
+func NewFile(path string, start uint, depth int) (*File, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("cursor.NewFile: depth %d is less than 1", depth)
+	}
+
+	self := File{
+		path:  path,
+		depth: depth,
+		state: fileState{Curr: start},
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &self, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("cursor.NewFile: %w", err)
+	}
+
+	err = json.Unmarshal(b, &self.state)
+	if err != nil {
+		return nil, fmt.Errorf("cursor.NewFile: decoding %s: %w", path, err)
+	}
+	return &self, nil
+}
+
+func (r *File) Curr() uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state.Curr
+}
+
+// Adv advances without remembering the block, which also forgets all earlier
+// ones since they are no longer contiguous.
+func (r *File) Adv() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state := fileState{Curr: r.state.Curr + 1}
+	return r.save(state)
+}
+
+func (r *File) AdvBlock(id string, events []txevent.E) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	blocks := append(r.state.Blocks, fileBlock{
+		Num:    r.state.Curr,
+		ID:     id,
+		Events: events,
+	})
+	if len(blocks) > r.depth {
+		blocks = blocks[len(blocks)-r.depth:]
+	}
+
+	state := fileState{Curr: r.state.Curr + 1, Blocks: blocks}
+	return r.save(state)
+}
+
+func (r *File) Block(num uint) (string, []txevent.E, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, b := range r.state.Blocks {
+		if b.Num == num {
+			return b.ID, b.Events, true
+		}
+	}
+	return "", nil, false
+}
+
+func (r *File) Rewind(num uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if num > r.state.Curr {
+		return fmt.Errorf("rewinding to %d: cursor is at %d", num, r.state.Curr)
+	}
+
+	var blocks []fileBlock
+	for _, b := range r.state.Blocks {
+		if b.Num < num {
+			blocks = append(blocks, b)
+		}
+	}
+
+	state := fileState{Curr: num, Blocks: blocks}
+	return r.save(state)
+}
+
+// save persists state before making it the current one.
+func (r *File) save(state fileState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding cursor: %w", err)
+	}
+
+	err = atomicfile.Write(r.path, b)
+	if err != nil {
+		return err
+	}
+
+	r.state = state
+	return nil
+}
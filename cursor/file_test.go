@@ -0,0 +1,57 @@
+package cursor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cursor.json")
+
+	c, err := NewFile(path, 100, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(100), c.Curr())
+
+	assert.NoError(t, c.AdvBlock("a", nil))
+	assert.NoError(t, c.AdvBlock("b", []txevent.E{{Hash: "t1", Amount: 5}}))
+	assert.NoError(t, c.AdvBlock("c", nil))
+	assert.Equal(t, uint(103), c.Curr())
+
+	// only the last 2 blocks are remembered
+	_, _, ok := c.Block(100)
+	assert.False(t, ok)
+
+	c, err = NewFile(path, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(103), c.Curr())
+
+	id, events, ok := c.Block(101)
+	assert.True(t, ok)
+	assert.Equal(t, "b", id)
+	assert.Equal(t, "t1", events[0].Hash)
+	assert.Equal(t, 5, events[0].Amount)
+
+	assert.NoError(t, c.Rewind(102))
+	assert.Equal(t, uint(102), c.Curr())
+	_, _, ok = c.Block(102)
+	assert.False(t, ok)
+	_, _, ok = c.Block(101)
+	assert.True(t, ok)
+	assert.Error(t, c.Rewind(103))
+
+	// plain Adv forgets the remembered blocks
+	assert.NoError(t, c.Adv())
+	_, _, ok = c.Block(101)
+	assert.False(t, ok)
+
+	// without remembered blocks reorgs would go unnoticed
+	_, err = NewFile(path, 0, 0)
+	assert.Error(t, err)
+	_, err = NewFile(path, 0, -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	assert.NoError(t, Write(path, []byte("1")))
+	assert.NoError(t, Write(path, []byte("2")))
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", string(b))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// a parent that is not a directory fails before anything is replaced
+	assert.Error(t, Write(filepath.Join(path, "x"), []byte("3")))
+}
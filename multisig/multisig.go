@@ -0,0 +1,94 @@
+package multisig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+)
+
+// Signer is implemented by the wallets.
+type Signer interface {
+	Addr() string
+	SignTx(tx *trongrid.Tx) error
+}
+
+// UpdatePermissions replaces the permissions of the account of s. The update
+// is signed by s alone, so it must hold the current owner permission.
+func UpdatePermissions(ctx context.Context, trongrid *trongrid.Client, s Signer, owner trongrid.Permission, actives []trongrid.Permission) (string, error) {
+	tx, err := trongrid.AccountPermissionUpdate(ctx, s.Addr(), owner, actives, nil)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.SignTx(tx)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := trongrid.Broadcast(ctx, *tx)
+	if err != nil {
+		return "", fmt.Errorf("broadcasting permission update: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Encode serializes a partially signed transaction, so it can be passed on
+// to the next signer.
+func Encode(tx trongrid.Tx) ([]byte, error) {
+	b, err := json.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("encoding tx: %w", err)
+	}
+	return b, nil
+}
+
+// Decode parses a transaction serialized by Encode and verifies it.
+func Decode(b []byte) (*trongrid.Tx, error) {
+	var tx trongrid.Tx
+	err := json.Unmarshal(b, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("decoding tx: %w", err)
+	}
+	if tx.RawDataHex == "" {
+		return nil, fmt.Errorf("decoding tx: raw data hex is empty")
+	}
+
+	err = Verify(tx)
+	if err != nil {
+		return nil, fmt.Errorf("decoding tx: %w", err)
+	}
+	return &tx, nil
+}
+
+// Sign verifies tx and adds the signature of s, so a signer never signs
+// something other than what the raw data shows.
+func Sign(s Signer, tx *trongrid.Tx) error {
+	err := Verify(*tx)
+	if err != nil {
+		return fmt.Errorf("verifying tx: %w", err)
+	}
+	return s.SignTx(tx)
+}
+
+// Broadcast sends tx to the network once its signatures reach the threshold
+// of its permission.
+func Broadcast(ctx context.Context, trongrid *trongrid.Client, tx trongrid.Tx) (string, error) {
+	weight, err := trongrid.SignWeight(ctx, tx)
+	if err != nil {
+		return "", err
+	}
+	if !weight.Enough() {
+		return "", fmt.Errorf("not enough signatures: %s: %d of %d (%s)",
+			weight.Result.Code, weight.CurrentWeight, weight.Permission.Threshold, weight.Result.Message)
+	}
+
+	hash, err := trongrid.Broadcast(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("broadcasting tx: %w", err)
+	}
+
+	return hash, nil
+}
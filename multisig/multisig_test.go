@@ -0,0 +1,200 @@
+package multisig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshuayildiz/wallet/chain"
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/joshuayildiz/wallet/trx"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const createdTx = `{
+	"visible": true,
+	"txID": "3d9e5effb7a6e9724df58915bffe60dd2d8a11541d4444347113642d4f94ca4b",
+	"raw_data": {
+		"contract": [{
+			"parameter": {
+				"value": {"amount": 1000, "owner_address": "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC", "to_address": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+				"type_url": "type.googleapis.com/protocol.TransferContract"
+			},
+			"type": "TransferContract",
+			"Permission_id": 2
+		}],
+		"ref_block_bytes": "a1b2",
+		"ref_block_hash": "0102030405060708",
+		"expiration": 1700000060000,
+		"timestamp": 1700000000000,
+		"unknown_field": "kept"
+	},
+	"raw_data_hex": "0a02a1b22208010203040506070840e0a499ffbc315a68080112620a2d747970652e676f6f676c65617069732e636f6d2f70726f746f636f6c2e5472616e73666572436f6e747261637412310a15417e5f4552091a69125d5dfcb7b8c2659029395bdf121541a614f803b6fd780986a42c78ec9c7f77e6ded13c18e80728027080d095ffbc31"
+}`
+
+func TestMultisig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var broadcast []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tx trongrid.Tx
+		err := json.NewDecoder(r.Body).Decode(&tx)
+		assert.NoError(t, err)
+
+		switch r.URL.Path {
+		case "/wallet/getsignweight":
+			code := "NOT_ENOUGH_PERMISSION"
+			if len(tx.Signature) >= 2 {
+				code = "ENOUGH_PERMISSION"
+			}
+			w.Write([]byte(`{"result": {"code": "` + code + `"}, "permission": {"threshold": 2}}`))
+
+		case "/wallet/broadcasttransaction":
+			broadcast, _ = json.Marshal(tx)
+			w.Write([]byte(`{"result": true, "txid": "` + tx.TxID + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := trongrid.New(chain.Mainnet, "", trongrid.WithBaseURL(server.URL))
+
+	var tx trongrid.Tx
+	err := json.Unmarshal([]byte(createdTx), &tx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tx.RawData.Contract[0].PermissionID)
+
+	alice, err := trx.New(client)
+	assert.NoError(t, err)
+	bob, err := trx.New(client)
+	assert.NoError(t, err)
+
+	err = Sign(alice, &tx)
+	assert.NoError(t, err)
+
+	_, err = Broadcast(ctx, client, tx)
+	assert.Error(t, err)
+
+	// pass the transaction on to the next signer
+	b, err := Encode(tx)
+	assert.NoError(t, err)
+	next, err := Decode(b)
+	assert.NoError(t, err)
+
+	err = Sign(bob, next)
+	assert.NoError(t, err)
+	assert.Len(t, next.Signature, 2)
+
+	hash, err := Broadcast(ctx, client, *next)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.TxID, hash)
+	assert.Contains(t, string(broadcast), `"unknown_field":"kept"`)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	var tx trongrid.Tx
+	err := json.Unmarshal([]byte(createdTx), &tx)
+	assert.NoError(t, err)
+	assert.NoError(t, Verify(tx))
+
+	// raw data shows a different amount than what gets signed
+	tampered := strings.Replace(createdTx, `"amount": 1000`, `"amount": 10`, 1)
+	_, err = Decode([]byte(tampered))
+	assert.ErrorContains(t, err, "amount")
+
+	tampered = strings.Replace(createdTx, `"txID": "3d`, `"txID": "4d`, 1)
+	_, err = Decode([]byte(tampered))
+	assert.ErrorContains(t, err, "hash")
+
+	err = json.Unmarshal([]byte(tampered), &tx)
+	assert.NoError(t, err)
+	signer, err := trx.New(nil)
+	assert.NoError(t, err)
+	assert.Error(t, Sign(signer, &tx))
+	assert.Empty(t, tx.Signature)
+}
+
+func TestVerifyHidden(t *testing.T) {
+	t.Parallel()
+
+	alice := tronaddr.MustParse("TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC")
+	usdt := tronaddr.MustParse("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	trigger := protowire.AppendTag(nil, 1, protowire.BytesType)
+	trigger = protowire.AppendBytes(trigger, alice[:])
+	trigger = protowire.AppendTag(trigger, 2, protowire.BytesType)
+	trigger = protowire.AppendBytes(trigger, usdt[:])
+	trigger = protowire.AppendTag(trigger, 3, protowire.VarintType)
+	trigger = protowire.AppendVarint(trigger, 1_000_000)
+	trigger = protowire.AppendTag(trigger, 4, protowire.BytesType)
+	trigger = protowire.AppendBytes(trigger, []byte{0xa9, 0x05, 0x9c, 0xbb})
+
+	shown := `{
+		"contract": [{
+			"parameter": {
+				"value": {"owner_address": "` + alice.String() + `", "contract_address": "` + usdt.String() + `", "data": "a9059cbb", "call_value": 1000000},
+				"type_url": "type.googleapis.com/protocol.TriggerSmartContract"
+			},
+			"type": "TriggerSmartContract"
+		}],
+		"data": "6d656d6f"
+	}`
+	raw := rawTx(31, "TriggerSmartContract", trigger, []byte("memo"))
+	assert.NoError(t, Verify(verifyTx(t, raw, shown)))
+
+	// trx sent along with the call
+	hidden := strings.Replace(shown, `, "call_value": 1000000`, "", 1)
+	assert.ErrorContains(t, Verify(verifyTx(t, raw, hidden)), "call_value")
+
+	// memo
+	hidden = strings.Replace(shown, `"data": "6d656d6f"`, `"data": ""`, 1)
+	assert.ErrorContains(t, Verify(verifyTx(t, raw, hidden)), "data")
+
+	// fields raw data can not show at all
+	extra := protowire.AppendTag(trigger, 7, protowire.VarintType)
+	extra = protowire.AppendVarint(extra, 1)
+	assert.ErrorContains(t, Verify(verifyTx(t, rawTx(31, "TriggerSmartContract", extra, []byte("memo")), shown)), "field 7")
+
+	// contracts whose values are not compared
+	update := strings.ReplaceAll(shown, "TriggerSmartContract", "AccountPermissionUpdateContract")
+	raw = rawTx(46, "AccountPermissionUpdateContract", trigger, []byte("memo"))
+	assert.ErrorContains(t, Verify(verifyTx(t, raw, update)), "can not be verified")
+}
+
+// rawTx encodes the raw data of a transaction with one contract.
+func rawTx(typ protowire.Number, name string, value, memo []byte) []byte {
+	param := protowire.AppendTag(nil, 1, protowire.BytesType)
+	param = protowire.AppendString(param, "type.googleapis.com/protocol."+name)
+	param = protowire.AppendTag(param, 2, protowire.BytesType)
+	param = protowire.AppendBytes(param, value)
+
+	contract := protowire.AppendTag(nil, 1, protowire.VarintType)
+	contract = protowire.AppendVarint(contract, uint64(typ))
+	contract = protowire.AppendTag(contract, 2, protowire.BytesType)
+	contract = protowire.AppendBytes(contract, param)
+
+	raw := protowire.AppendTag(nil, 10, protowire.BytesType)
+	raw = protowire.AppendBytes(raw, memo)
+	raw = protowire.AppendTag(raw, 11, protowire.BytesType)
+	return protowire.AppendBytes(raw, contract)
+}
+
+func verifyTx(t *testing.T, raw []byte, rawData string) trongrid.Tx {
+	var tx trongrid.Tx
+	err := json.Unmarshal([]byte(rawData), &tx.RawData)
+	assert.NoError(t, err)
+	sum := sha256.Sum256(raw)
+	tx.TxID = hex.EncodeToString(sum[:])
+	tx.RawDataHex = hex.EncodeToString(raw)
+	return tx
+}
@@ -0,0 +1,221 @@
+package multisig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const typeURLPrefix = "type.googleapis.com/protocol."
+
+// Verify checks that a transaction received from someone else is what it
+// claims to be. The txID must be the hash of raw_data_hex, and raw_data,
+// which is what signers look at, must describe the same transaction as
+// raw_data_hex, which is what they sign. Every field present in raw_data_hex
+// has to be compared, so transactions with contract types or fields Verify
+// does not know are rejected.
+func Verify(tx trongrid.Tx) error {
+	raw, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil {
+		return fmt.Errorf("raw data hex is invalid: %w", err)
+	}
+
+	sum := sha256.Sum256(raw)
+	if !strings.EqualFold(tx.TxID, hex.EncodeToString(sum[:])) {
+		return fmt.Errorf("tx id %s is not the hash of the raw data", tx.TxID)
+	}
+
+	fields, err := decodeFields(raw)
+	if err != nil {
+		return fmt.Errorf("decoding raw data hex: %w", err)
+	}
+
+	err = errors.Join(
+		fields.only(1, 4, 8, 10, 11, 14, 18),
+		matchBytes("ref_block_bytes", tx.RawData.RefBlockBytes, fields.bytes(1)),
+		matchBytes("ref_block_hash", tx.RawData.RefBlockHash, fields.bytes(4)),
+		matchUint("expiration", uint64(tx.RawData.Expiration), fields.uint(8)),
+		matchBytes("data", tx.RawData.Data, fields.bytes(10)),
+		matchUint("timestamp", uint64(tx.RawData.Timestamp), fields.uint(14)),
+		matchUint("fee_limit", uint64(tx.RawData.FeeLimit), fields.uint(18)),
+	)
+	if err != nil {
+		return fmt.Errorf("raw data does not match raw data hex: %w", err)
+	}
+
+	contracts := fields[11]
+	if len(contracts) != len(tx.RawData.Contract) {
+		return fmt.Errorf("raw data does not match raw data hex: %d contracts instead of %d", len(tx.RawData.Contract), len(contracts))
+	}
+	for i, c := range tx.RawData.Contract {
+		err = verifyContract(c, contracts[i].b)
+		if err != nil {
+			return fmt.Errorf("raw data does not match raw data hex: contract %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func verifyContract(c trongrid.Contract, b []byte) error {
+	fields, err := decodeFields(b)
+	if err != nil {
+		return err
+	}
+	param, err := decodeFields(fields.bytes(2))
+	if err != nil {
+		return fmt.Errorf("decoding parameter: %w", err)
+	}
+
+	typeURL := string(param.bytes(1))
+	if c.Type != strings.TrimPrefix(typeURL, typeURLPrefix) {
+		return fmt.Errorf("type is %s instead of %s", c.Type, typeURL)
+	}
+	if c.Parameter.TypeURL != typeURL {
+		return fmt.Errorf("type url is %s instead of %s", c.Parameter.TypeURL, typeURL)
+	}
+
+	err = errors.Join(
+		fields.only(1, 2, 5),
+		param.only(1, 2),
+		matchUint("Permission_id", uint64(c.PermissionID), fields.uint(5)),
+	)
+	if err != nil {
+		return err
+	}
+
+	value, err := decodeFields(param.bytes(2))
+	if err != nil {
+		return fmt.Errorf("decoding value: %w", err)
+	}
+
+	v := c.Parameter.Value
+	switch c.Type {
+	case "TransferContract":
+		return errors.Join(
+			value.only(1, 2, 3),
+			matchAddr("owner_address", v.OwnerAddress, value.bytes(1)),
+			matchAddr("to_address", v.ToAddress, value.bytes(2)),
+			matchUint("amount", uint64(v.Amount), value.uint(3)),
+		)
+	case "TransferAssetContract":
+		return errors.Join(
+			value.only(1, 2, 3, 4),
+			matchName("asset_name", v.AssetName, value.bytes(1)),
+			matchAddr("owner_address", v.OwnerAddress, value.bytes(2)),
+			matchAddr("to_address", v.ToAddress, value.bytes(3)),
+			matchUint("amount", uint64(v.Amount), value.uint(4)),
+		)
+	case "TriggerSmartContract":
+		return errors.Join(
+			value.only(1, 2, 3, 4, 5, 6),
+			matchAddr("owner_address", v.OwnerAddress, value.bytes(1)),
+			matchAddr("contract_address", v.ContractAddress, value.bytes(2)),
+			matchUint("call_value", uint64(v.CallValue), value.uint(3)),
+			matchBytes("data", v.Data, value.bytes(4)),
+			matchUint("call_token_value", uint64(v.CallTokenValue), value.uint(5)),
+			matchUint("token_id", uint64(v.TokenID), value.uint(6)),
+		)
+	}
+	return fmt.Errorf("contract type %s can not be verified", c.Type)
+}
+
+type field struct {
+	v uint64
+	b []byte
+}
+
+// fields of a protobuf message by field number.
+type fields map[protowire.Number][]field
+
+func decodeFields(b []byte) (fields, error) {
+	out := make(fields)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var f field
+		switch typ {
+		case protowire.VarintType:
+			f.v, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.b, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		out[num] = append(out[num], f)
+	}
+	return out, nil
+}
+
+// only reports an error for fields other than nums, which would be signed
+// without being shown.
+func (r fields) only(nums ...protowire.Number) error {
+	for num := range r {
+		if !slices.Contains(nums, num) {
+			return fmt.Errorf("field %d is not shown", num)
+		}
+	}
+	return nil
+}
+
+func (r fields) uint(num protowire.Number) uint64 {
+	if len(r[num]) == 0 {
+		return 0
+	}
+	return r[num][len(r[num])-1].v
+}
+
+func (r fields) bytes(num protowire.Number) []byte {
+	if len(r[num]) == 0 {
+		return nil
+	}
+	return r[num][len(r[num])-1].b
+}
+
+func matchUint(name string, got, want uint64) error {
+	if got != want {
+		return fmt.Errorf("%s is %d instead of %d", name, got, want)
+	}
+	return nil
+}
+
+func matchBytes(name, got string, want []byte) error {
+	b, err := hex.DecodeString(got)
+	if err != nil || !bytes.Equal(b, want) {
+		return fmt.Errorf("%s is %s instead of %x", name, got, want)
+	}
+	return nil
+}
+
+// matchName accepts names as text like with visible set and as hex.
+func matchName(name, got string, want []byte) error {
+	if got == string(want) {
+		return nil
+	}
+	return matchBytes(name, got, want)
+}
+
+func matchAddr(name, got string, want []byte) error {
+	addr, err := tronaddr.Parse(got)
+	if err != nil || !bytes.Equal(addr[:], want) {
+		return fmt.Errorf("%s is %s instead of %x", name, got, want)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package sweep
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/joshuayildiz/wallet/internal/atomicfile"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+type State string
+
+const (
+	// Intent to top up was recorded, a top up may have been sent but the
+	// sweep itself was not.
+	StateFunding State = "funding"
+
+	// Intent to send was recorded, the outcome is unknown.
+	StatePending State = "pending"
+
+	// Send returned an error after the transaction may have been broadcast.
+	StateFailed State = "failed"
+
+	// Transaction was broadcast.
+	StateSent State = "sent"
+)
+
+type Entry struct {
+	RunID     string           `json:"run_id"`
+	Addr      string           `json:"addr"`
+	Currency  txevent.Currency `json:"currency"`
+	Amount    uint             `json:"amount"`
+	Hash      string           `json:"hash"`
+	TopUp     uint             `json:"top_up"`
+	TopUpHash string           `json:"top_up_hash"`
+	State     State            `json:"state"`
+
+	// Balance of the source before sweeping, used to find out whether an
+	// unfinished sweep landed.
+	Balance uint `json:"balance"`
+
+	// Error returned by Send, set in StateFailed.
+	Err string `json:"err,omitempty"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Journal durably records sweeps. Save must not return before the entry is
+// persisted, otherwise a crash can lead to sweeping twice.
+type Journal interface {
+	Load(runID, addr string) (Entry, bool, error)
+	Save(e Entry) error
+	Delete(runID, addr string) error
+}
+
+// FileJournal keeps one json file per entry in a directory.
+type FileJournal struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileJournal(dir string) (*FileJournal, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("sweep.NewFileJournal: %w", err)
+	}
+
+	self := FileJournal{dir: dir}
+	return &self, nil
+}
+
+func (r *FileJournal) Load(runID, addr string) (Entry, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := os.ReadFile(r.path(runID, addr))
+	if errors.Is(err, os.ErrNotExist) {
+		return Entry{}, false, nil
+	} else if err != nil {
+		return Entry{}, false, err
+	}
+
+	var e Entry
+	err = json.Unmarshal(b, &e)
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("decoding entry: %w", err)
+	}
+	return e, true, nil
+}
+
+func (r *FileJournal) Save(e Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding entry: %w", err)
+	}
+
+	return atomicfile.Write(r.path(e.RunID, e.Addr), b)
+}
+
+func (r *FileJournal) Delete(runID, addr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := os.Remove(r.path(runID, addr))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (r *FileJournal) path(runID, addr string) string {
+	return filepath.Join(r.dir, filepath.Base(runID)+"_"+filepath.Base(addr)+".json")
+}
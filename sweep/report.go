@@ -0,0 +1,74 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+type Report struct {
+	RunID   string
+	Results []Result
+}
+
+type Result struct {
+	Addr     string
+	Currency txevent.Currency
+	Amount   uint
+
+	// Empty if the sweep of an earlier attempt was found on chain by its
+	// balance change only.
+	Hash      string
+	TopUp     uint
+	TopUpHash string
+	Fee       int
+
+	// Balance was below the threshold.
+	Skipped bool
+
+	// Result was taken from the journal of an earlier attempt.
+	Resumed bool
+
+	Err error
+}
+
+// Swept returns the total amount swept per currency.
+func (r *Report) Swept() map[txevent.Currency]uint {
+	out := make(map[txevent.Currency]uint)
+	for _, res := range r.Results {
+		if !res.Skipped && res.Err == nil {
+			out[res.Currency] += res.Amount
+		}
+	}
+	return out
+}
+
+// Failed returns all results with an error.
+func (r *Report) Failed() []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if res.Err != nil {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Settle fills in the fees paid by the sweep transactions. It only works once
+// the transactions are solidified.
+func (r *Report) Settle(ctx context.Context, trongrid trongrid.Backend) error {
+	for i, res := range r.Results {
+		if res.Hash == "" {
+			continue
+		}
+
+		info, err := trongrid.TxInfoByID(ctx, res.Hash)
+		if err != nil {
+			return fmt.Errorf("settling %s: %w", res.Addr, err)
+		}
+		r.Results[i].Fee = info.Fee
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package sweep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/joshuayildiz/wallet"
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// Source is a deposit wallet that gets swept.
+type Source struct {
+	Wallet   wallet.Wallet
+	Currency txevent.Currency
+}
+
+// Funder makes sure an address can pay the fees of a sweep, e.g. by sending
+// it some TRX or by delegating energy to it. It returns the transaction hash
+// and amount of the top up, both empty if none was needed.
+type Funder interface {
+	Fund(ctx context.Context, addr string) (string, uint, error)
+}
+
+type Config struct {
+	// Address all funds are swept to.
+	Target string
+
+	// Sources with a balance below their currency threshold are skipped.
+	Thresholds map[txevent.Currency]uint
+
+	// Amount of sun left on TRX sources to pay for the sweep itself.
+	TRXReserve uint
+
+	// Used to top up TRC-20 sources before sweeping, may be nil.
+	Funder Funder
+
+	// Maximum number of sources swept at the same time, defaults to 1.
+	Parallelism int
+
+	// Records progress so an interrupted run can be resumed.
+	Journal Journal
+
+	// Identifies a run in the journal. Resuming a crashed run requires
+	// passing the same id again.
+	RunID string
+
+	// How long an unfinished sweep that did not show up on chain is left
+	// alone before it is retried, so a transaction that may have been
+	// broadcast either lands or expires first. Defaults to 2 minutes.
+	RetryAfter time.Duration
+}
+
+type Sweeper struct {
+	cfg Config
+}
+
+func New(cfg Config) (*Sweeper, error) {
+	if cfg.Target == "" {
+		return nil, errors.New("sweep.New: target is empty")
+	}
+	if cfg.Journal == nil {
+		return nil, errors.New("sweep.New: journal is nil")
+	}
+	if cfg.RunID == "" {
+		return nil, errors.New("sweep.New: run id is empty")
+	}
+	if cfg.Parallelism < 1 {
+		cfg.Parallelism = 1
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = 2 * time.Minute
+	}
+
+	self := Sweeper{cfg: cfg}
+	return &self, nil
+}
+
+// Run sweeps all sources to the target. Errors of single sources end up in
+// the report, only journal failures abort the run.
+func (r *Sweeper) Run(ctx context.Context, sources []Source) (*Report, error) {
+	report := Report{
+		RunID:   r.cfg.RunID,
+		Results: make([]Result, len(sources)),
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		fatalErr error
+	)
+	sem := make(chan struct{}, r.cfg.Parallelism)
+
+	for i, s := range sources {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			res, err := r.sweep(ctx, s)
+			if err != nil {
+				mu.Lock()
+				fatalErr = errors.Join(fatalErr, err)
+				mu.Unlock()
+			}
+			report.Results[i] = res
+		}()
+	}
+	wg.Wait()
+
+	if fatalErr != nil {
+		return &report, fmt.Errorf("sweep: %w", fatalErr)
+	}
+	return &report, nil
+}
+
+func (r *Sweeper) sweep(ctx context.Context, s Source) (Result, error) {
+	addr := s.Wallet.Addr()
+	res := Result{
+		Addr:     addr,
+		Currency: s.Currency,
+	}
+
+	entry, ok, err := r.cfg.Journal.Load(r.cfg.RunID, addr)
+	if err != nil {
+		return res, fmt.Errorf("loading journal entry of %s: %w", addr, err)
+	}
+	if ok {
+		// an entry means we already tried to send in this run, sending
+		// again could sweep the same funds twice
+		done, err := r.recover(ctx, s, entry, &res)
+		if done || err != nil {
+			return res, err
+		}
+	}
+
+	balance, err := s.Wallet.Balance(ctx)
+	if err != nil {
+		res.Err = fmt.Errorf("fetching balance: %w", err)
+		return res, nil
+	}
+
+	amt := balance
+	if s.Currency == txevent.TRX {
+		if amt <= r.cfg.TRXReserve {
+			res.Skipped = true
+			return res, nil
+		}
+		amt -= r.cfg.TRXReserve
+	}
+
+	threshold, ok := r.cfg.Thresholds[s.Currency]
+	if !ok || amt < threshold {
+		res.Skipped = true
+		return res, nil
+	}
+	res.Amount = amt
+
+	entry = Entry{
+		RunID:    r.cfg.RunID,
+		Addr:     addr,
+		Currency: s.Currency,
+		Amount:   amt,
+		Balance:  balance,
+	}
+
+	if s.Currency != txevent.TRX && r.cfg.Funder != nil {
+		// the top up is journaled before it is sent, so a crash right
+		// after it does not lead to funding the address again
+		entry.State = StateFunding
+		err = r.save(&entry)
+		if err != nil {
+			return res, err
+		}
+
+		hash, topUp, err := r.cfg.Funder.Fund(ctx, addr)
+		if err != nil {
+			res.Err = fmt.Errorf("funding fees: %w", err)
+			return res, r.failed(&entry, err)
+		}
+		res.TopUpHash = hash
+		res.TopUp = topUp
+		entry.TopUpHash = hash
+		entry.TopUp = topUp
+	}
+
+	entry.State = StatePending
+	err = r.save(&entry)
+	if err != nil {
+		return res, err
+	}
+
+	hash, err := s.Wallet.Send(ctx, r.cfg.Target, amt)
+	if err != nil {
+		res.Err = fmt.Errorf("sending: %w", err)
+		return res, r.failed(&entry, err)
+	}
+	res.Hash = hash
+
+	entry.Hash = hash
+	entry.State = StateSent
+	return res, r.save(&entry)
+}
+
+// recover looks at the chain to find out how an unfinished sweep of an
+// earlier attempt ended. It returns true if the source must not be swept in
+// this attempt.
+func (r *Sweeper) recover(ctx context.Context, s Source, entry Entry, res *Result) (bool, error) {
+	res.Amount = entry.Amount
+	res.Hash = entry.Hash
+	res.TopUpHash = entry.TopUpHash
+	res.TopUp = entry.TopUp
+	res.Resumed = true
+	if entry.State == StateSent {
+		return true, nil
+	}
+
+	balance, err := s.Wallet.Balance(ctx)
+	if err != nil {
+		res.Err = fmt.Errorf("fetching balance to check unfinished sweep: %w", err)
+		return true, nil
+	}
+
+	// a top up does not change the balance being swept, so only a sweep
+	// that may have been sent can explain a drop
+	if entry.State != StateFunding && balance < entry.Balance {
+		entry.State = StateSent
+		entry.Err = ""
+		return true, r.save(&entry)
+	}
+
+	retryAt := entry.UpdatedAt.Add(r.cfg.RetryAfter)
+	if time.Now().Before(retryAt) {
+		res.Err = fmt.Errorf("sweep of %s is %s and may still land, retry after %s", entry.Addr, entry.State, retryAt.Format(time.RFC3339))
+		return true, nil
+	}
+
+	// whatever was broadcast has expired by now without moving the funds
+	err = r.cfg.Journal.Delete(r.cfg.RunID, entry.Addr)
+	if err != nil {
+		return true, fmt.Errorf("deleting journal entry of %s: %w", entry.Addr, err)
+	}
+	*res = Result{Addr: res.Addr, Currency: res.Currency}
+	return false, nil
+}
+
+// failed records an error of Fund or Send. Errors that happened before
+// anything was broadcast drop the entry so the next attempt starts over,
+// all others keep it until the chain shows what happened.
+func (r *Sweeper) failed(entry *Entry, err error) error {
+	var notBroadcastErr *trongrid.NotBroadcastError
+	if errors.As(err, &notBroadcastErr) {
+		err = r.cfg.Journal.Delete(entry.RunID, entry.Addr)
+		if err != nil {
+			return fmt.Errorf("deleting journal entry of %s: %w", entry.Addr, err)
+		}
+		return nil
+	}
+
+	if entry.State == StatePending {
+		entry.State = StateFailed
+	}
+	entry.Err = err.Error()
+	return r.save(entry)
+}
+
+func (r *Sweeper) save(entry *Entry) error {
+	entry.UpdatedAt = time.Now()
+	err := r.cfg.Journal.Save(*entry)
+	if err != nil {
+		return fmt.Errorf("saving journal entry of %s: %w", entry.Addr, err)
+	}
+	return nil
+}
+
+// TRXFunder tops up addresses from a TRX wallet whenever their TRX balance
+// drops below Min.
+type TRXFunder struct {
+	Trongrid trongrid.Backend
+	Wallet   wallet.Wallet
+	Min      uint
+	Amount   uint
+}
+
+func (r *TRXFunder) Fund(ctx context.Context, addr string) (string, uint, error) {
+	balance, err := r.Trongrid.Balance(ctx, addr)
+	if err != nil {
+		return "", 0, &trongrid.NotBroadcastError{Err: err}
+	}
+	if balance >= r.Min {
+		return "", 0, nil
+	}
+
+	hash, err := r.Wallet.Send(ctx, addr, r.Amount)
+	if err != nil {
+		return "", 0, fmt.Errorf("sending trx to %s: %w", addr, err)
+	}
+	return hash, r.Amount, nil
+}
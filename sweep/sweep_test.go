@@ -0,0 +1,184 @@
+package sweep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/chain/trongrid"
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweep(t *testing.T) {
+	t.Parallel()
+
+	journal, err := NewFileJournal(t.TempDir())
+	assert.NoError(t, err)
+
+	funder := &memFunder{}
+	sweeper, err := New(Config{
+		Target: "target",
+		Thresholds: map[txevent.Currency]uint{
+			txevent.TRX:       1_000_000,
+			txevent.TRON_USDT: 5_000_000,
+		},
+		TRXReserve:  100_000,
+		Funder:      funder,
+		Parallelism: 2,
+		Journal:     journal,
+		RunID:       "run",
+	})
+	assert.NoError(t, err)
+
+	sources := []Source{
+		{Wallet: &memWallet{addr: "a", balance: 2_000_000}, Currency: txevent.TRX},
+		{Wallet: &memWallet{addr: "b", balance: 500_000}, Currency: txevent.TRX},
+		{Wallet: &memWallet{addr: "c", balance: 10_000_000}, Currency: txevent.TRON_USDT},
+		{Wallet: &memWallet{addr: "d", balance: 10_000_000, sendErr: errors.New("boom")}, Currency: txevent.TRON_USDT},
+	}
+
+	report, err := sweeper.Run(context.Background(), sources)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint(1_900_000), report.Results[0].Amount)
+	assert.True(t, report.Results[1].Skipped)
+	assert.Equal(t, "topup-c", report.Results[2].TopUpHash)
+	assert.Len(t, report.Failed(), 1)
+	assert.Equal(t, map[txevent.Currency]uint{
+		txevent.TRX:       1_900_000,
+		txevent.TRON_USDT: 10_000_000,
+	}, report.Swept())
+
+	// running again must not send anything twice
+	report, err = sweeper.Run(context.Background(), sources)
+	assert.NoError(t, err)
+	assert.True(t, report.Results[0].Resumed)
+	assert.True(t, report.Results[2].Resumed)
+	assert.Equal(t, 1, sources[0].Wallet.(*memWallet).sent)
+	assert.Equal(t, 1, sources[2].Wallet.(*memWallet).sent)
+}
+
+func TestSweepInterrupted(t *testing.T) {
+	t.Parallel()
+
+	journal, err := NewFileJournal(t.TempDir())
+	assert.NoError(t, err)
+
+	sweeper, err := New(Config{
+		Target:     "target",
+		Thresholds: map[txevent.Currency]uint{txevent.TRX: 1},
+		Journal:    journal,
+		RunID:      "run",
+		RetryAfter: time.Minute,
+	})
+	assert.NoError(t, err)
+
+	// balance did not change and the tx may still land
+	err = journal.Save(Entry{RunID: "run", Addr: "a", Amount: 10, Balance: 10, State: StatePending, UpdatedAt: time.Now()})
+	assert.NoError(t, err)
+
+	w := &memWallet{addr: "a", balance: 10}
+	report, err := sweeper.Run(context.Background(), []Source{{Wallet: w, Currency: txevent.TRX}})
+	assert.NoError(t, err)
+	assert.Error(t, report.Results[0].Err)
+	assert.Equal(t, 0, w.sent)
+
+	// balance dropped, so the sweep landed
+	w.balance = 0
+	report, err = sweeper.Run(context.Background(), []Source{{Wallet: w, Currency: txevent.TRX}})
+	assert.NoError(t, err)
+	assert.NoError(t, report.Results[0].Err)
+	assert.True(t, report.Results[0].Resumed)
+	assert.Equal(t, 0, w.sent)
+
+	entry, _, err := journal.Load("run", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, StateSent, entry.State)
+
+	// balance did not change long after the failure, so it gets retried
+	err = journal.Save(Entry{RunID: "run", Addr: "b", Amount: 10, Balance: 10, State: StateFailed, UpdatedAt: time.Now().Add(-time.Hour)})
+	assert.NoError(t, err)
+
+	w = &memWallet{addr: "b", balance: 10}
+	report, err = sweeper.Run(context.Background(), []Source{{Wallet: w, Currency: txevent.TRX}})
+	assert.NoError(t, err)
+	assert.NoError(t, report.Results[0].Err)
+	assert.False(t, report.Results[0].Resumed)
+	assert.Equal(t, 1, w.sent)
+}
+
+func TestSweepSendError(t *testing.T) {
+	t.Parallel()
+
+	journal, err := NewFileJournal(t.TempDir())
+	assert.NoError(t, err)
+
+	sweeper, err := New(Config{
+		Target:     "target",
+		Thresholds: map[txevent.Currency]uint{txevent.TRX: 1},
+		Journal:    journal,
+		RunID:      "run",
+	})
+	assert.NoError(t, err)
+
+	sources := []Source{
+		{Wallet: &memWallet{addr: "a", balance: 10, sendErr: errors.New("timeout")}, Currency: txevent.TRX},
+		{Wallet: &memWallet{addr: "b", balance: 10, sendErr: &trongrid.NotBroadcastError{Err: errors.New("invalid recipient")}}, Currency: txevent.TRX},
+	}
+	report, err := sweeper.Run(context.Background(), sources)
+	assert.NoError(t, err)
+	assert.Len(t, report.Failed(), 2)
+
+	// the tx may have been broadcast before the error
+	entry, ok, err := journal.Load("run", "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, StateFailed, entry.State)
+	assert.Equal(t, "timeout", entry.Err)
+
+	_, ok, err = journal.Load("run", "b")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+type memWallet struct {
+	addr    string
+	balance uint
+	sendErr error
+
+	mu   sync.Mutex
+	sent int
+}
+
+func (r *memWallet) PrivKeyHex() string {
+	return ""
+}
+
+func (r *memWallet) Addr() string {
+	return r.addr
+}
+
+func (r *memWallet) Balance(ctx context.Context) (uint, error) {
+	return r.balance, nil
+}
+
+func (r *memWallet) Send(ctx context.Context, to string, amt uint) (string, error) {
+	if r.sendErr != nil {
+		return "", r.sendErr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent++
+	return fmt.Sprintf("hash-%s", r.addr), nil
+}
+
+type memFunder struct{}
+
+func (r *memFunder) Fund(ctx context.Context, addr string) (string, uint, error) {
+	return "topup-" + addr, 1_000_000, nil
+}
@@ -0,0 +1,168 @@
+package tronaddr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/sha3"
+)
+
+// Prefix is the first byte of every tron address. Shasta and nile use the
+// mainnet prefix as well.
+const Prefix = 0x41
+
+// Address is a tron address including its 0x41 prefix.
+type Address [21]byte
+
+var ErrInvalid = errors.New("invalid tron address")
+
+// Parse accepts base58check ("T..."), 41-prefixed hex and 0x evm hex.
+func Parse(s string) (Address, error) {
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		return ParseEVM(s)
+	case len(s) == 42:
+		return ParseHex(s)
+	default:
+		return ParseBase58(s)
+	}
+}
+
+// MustParse is like Parse but panics on invalid input. Meant for constants.
+func MustParse(s string) Address {
+	addr, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// Valid reports whether s can be parsed as an address.
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+func ParseBase58(s string) (Address, error) {
+	decoded := base58.Decode(s)
+	if len(decoded) != 25 {
+		return Address{}, fmt.Errorf("%w: %q has wrong length", ErrInvalid, s)
+	}
+
+	payload, checksum := decoded[:21], decoded[21:]
+	if !bytes.Equal(checksum, sum(payload)) {
+		return Address{}, fmt.Errorf("%w: %q has wrong checksum", ErrInvalid, s)
+	}
+
+	return FromBytes(payload)
+}
+
+// ParseHex parses the 41-prefixed hex form used by the node apis when
+// visible is false.
+func ParseHex(s string) (Address, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: %q is invalid hex", ErrInvalid, s)
+	}
+	if len(b) != 21 {
+		return Address{}, fmt.Errorf("%w: %q has wrong length", ErrInvalid, s)
+	}
+	return FromBytes(b)
+}
+
+// ParseEVM parses the 20 byte hex form used inside contracts and logs. The 0x
+// prefix is optional.
+func ParseEVM(s string) (Address, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	b, err := hex.DecodeString(trimmed)
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: %q is invalid hex", ErrInvalid, s)
+	}
+	if len(b) != 20 {
+		return Address{}, fmt.Errorf("%w: %q has wrong length", ErrInvalid, s)
+	}
+	return FromBytes(b)
+}
+
+// FromBytes accepts either 21 bytes including the prefix or 20 bytes without.
+func FromBytes(b []byte) (Address, error) {
+	var addr Address
+	switch len(b) {
+	case 21:
+		if b[0] != Prefix {
+			return Address{}, fmt.Errorf("%w: prefix is 0x%02x", ErrInvalid, b[0])
+		}
+		copy(addr[:], b)
+	case 20:
+		addr[0] = Prefix
+		copy(addr[1:], b)
+	default:
+		return Address{}, fmt.Errorf("%w: %d bytes", ErrInvalid, len(b))
+	}
+	return addr, nil
+}
+
+// FromPubKey derives the address of a public key, same as ethereum but with
+// the tron prefix.
+func FromPubKey(pubKey *secp256k1.PublicKey) Address {
+	uncompressed := pubKey.SerializeUncompressed()
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(uncompressed[1:])
+	keccak256 := hasher.Sum(nil)
+
+	var addr Address
+	addr[0] = Prefix
+	copy(addr[1:], keccak256[len(keccak256)-20:])
+	return addr
+}
+
+// String returns the base58check form.
+func (r Address) String() string {
+	both := append(r[:], sum(r[:])...)
+	return base58.Encode(both)
+}
+
+// Hex returns the 41-prefixed hex form.
+func (r Address) Hex() string {
+	return hex.EncodeToString(r[:])
+}
+
+// EVM returns the 0x prefixed 20 byte hex form.
+func (r Address) EVM() string {
+	return "0x" + hex.EncodeToString(r[1:])
+}
+
+// EVMBytes returns the 20 bytes without prefix.
+func (r Address) EVMBytes() []byte {
+	return bytes.Clone(r[1:])
+}
+
+func (r Address) IsZero() bool {
+	return r == Address{}
+}
+
+func (r Address) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Address) UnmarshalText(b []byte) error {
+	addr, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*r = addr
+	return nil
+}
+
+func sum(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
@@ -0,0 +1,52 @@
+package tronaddr
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	forms := []string{
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		"41a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"0xa614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"0xA614F803B6FD780986A42C78EC9C7F77E6DED13C",
+	}
+	for _, f := range forms {
+		addr, err := Parse(f)
+		assert.NoError(t, err, f)
+		assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", addr.String())
+		assert.Equal(t, "41a614f803b6fd780986a42c78ec9c7f77e6ded13c", addr.Hex())
+		assert.Equal(t, "0xa614f803b6fd780986a42c78ec9c7f77e6ded13c", addr.EVM())
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"",
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", // checksum
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj",   // length
+		"42a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"0xa614f803b6fd780986a42c78ec9c7f77e6ded1",
+		"0xzz14f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", // bitcoin
+	}
+	for _, s := range invalid {
+		assert.False(t, Valid(s), s)
+	}
+}
+
+func TestFromPubKey(t *testing.T) {
+	t.Parallel()
+
+	// private key 1, its ethereum address is well known
+	privKey := secp256k1.PrivKeyFromBytes([]byte{1})
+	addr := FromPubKey(privKey.PubKey())
+	assert.Equal(t, "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", addr.EVM())
+}
@@ -0,0 +1,117 @@
+package tronsig
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	decred_ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"golang.org/x/crypto/sha3"
+)
+
+// MessagePrefix is prepended to messages before hashing, so that a signed
+// message can never be a valid transaction.
+const MessagePrefix = "\x19TRON Signed Message:\n"
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Sign calculates an ECDSA signature.
+//
+// This function is susceptible to chosen plaintext attacks that can leak
+// information about the private key that is used for signing. Callers must
+// be aware that the given hash cannot be chosen by an adversary. Common
+// solution is to hash any input before calculating the signature.
+//
+// The produced signature is in the [R || S || V] format where V is 0 or 1.
+func Sign(hash []byte, privKey *secp256k1.PrivateKey) ([]byte, error) {
+	const DigestLength = 32
+	const RecoveryIDOffset = 64
+
+	if len(hash) != DigestLength {
+		return nil, fmt.Errorf("hash is required to be exactly %d bytes (%d)", DigestLength, len(hash))
+	}
+	if privKey.Key.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+	sig := decred_ecdsa.SignCompact(privKey, hash, false) // ref uncompressed pubkey
+	// Convert to Ethereum signature format with 'recovery id' v at the end.
+	v := sig[0] - 27
+	copy(sig, sig[1:])
+	sig[RecoveryIDOffset] = v
+	return sig, nil
+}
+
+// Recover returns the address that produced sig over hash. V may be 0, 1, 27
+// or 28.
+func Recover(hash []byte, sig []byte) (tronaddr.Address, error) {
+	if len(sig) != 65 {
+		return tronaddr.Address{}, fmt.Errorf("%w: length is %d", ErrInvalidSignature, len(sig))
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return tronaddr.Address{}, fmt.Errorf("%w: recovery id is %d", ErrInvalidSignature, sig[64])
+	}
+
+	// back to the [V || R || S] format decred expects
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+
+	pubKey, _, err := decred_ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return tronaddr.Address{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return tronaddr.FromPubKey(pubKey), nil
+}
+
+// HashMessage hashes msg the same way as tronweb's signMessageV2.
+func HashMessage(msg []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(MessagePrefix))
+	hasher.Write([]byte(strconv.Itoa(len(msg))))
+	hasher.Write(msg)
+	return hasher.Sum(nil)
+}
+
+// SignMessage signs msg like tronweb's signMessageV2. The result is 0x
+// prefixed hex in the [R || S || V] format where V is 27 or 28.
+func SignMessage(privKey *secp256k1.PrivateKey, msg []byte) (string, error) {
+	sig, err := Sign(HashMessage(msg), privKey)
+	if err != nil {
+		return "", err
+	}
+	sig[64] += 27
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+// VerifyMessage checks that sig was produced over msg by the key of addr.
+func VerifyMessage(addr string, msg []byte, sig string) error {
+	want, err := tronaddr.Parse(addr)
+	if err != nil {
+		return err
+	}
+
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+	if err != nil {
+		return fmt.Errorf("%w: invalid hex", ErrInvalidSignature)
+	}
+
+	got, err := Recover(HashMessage(msg), sigBytes)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: signed by %s", ErrInvalidSignature, got)
+	}
+
+	return nil
+}
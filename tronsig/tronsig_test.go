@@ -0,0 +1,45 @@
+package tronsig
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestHashMessage(t *testing.T) {
+	t.Parallel()
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte("\x19TRON Signed Message:\n11hello world"))
+	assert.Equal(t, hasher.Sum(nil), HashMessage([]byte("hello world")))
+}
+
+func TestSignVerifyMessage(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := secp256k1.GeneratePrivateKey()
+	assert.NoError(t, err)
+	addr := tronaddr.FromPubKey(privKey.PubKey()).String()
+
+	msg := []byte("login nonce 1234")
+	sig, err := SignMessage(privKey, msg)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 2+65*2)
+
+	assert.NoError(t, VerifyMessage(addr, msg, sig))
+	assert.ErrorIs(t, VerifyMessage(addr, []byte("login nonce 1235"), sig), ErrInvalidSignature)
+
+	other, err := secp256k1.GeneratePrivateKey()
+	assert.NoError(t, err)
+	otherAddr := tronaddr.FromPubKey(other.PubKey()).String()
+	assert.ErrorIs(t, VerifyMessage(otherAddr, msg, sig), ErrInvalidSignature)
+
+	// v as 0 or 1 is accepted as well
+	sigBytes, _ := hex.DecodeString(sig[2:])
+	sigBytes[64] -= 27
+	assert.NoError(t, VerifyMessage(addr, msg, hex.EncodeToString(sigBytes)))
+}
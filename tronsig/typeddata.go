@@ -0,0 +1,369 @@
+package tronsig
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/joshuayildiz/wallet/abi"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"golang.org/x/crypto/sha3"
+)
+
+// TypedData is a TIP-712 structured message. It has the same json layout as
+// the EIP-712 one, addresses may be given in any form tronaddr can parse.
+type TypedData struct {
+	Types       map[string][]TypedField `json:"types"`
+	PrimaryType string                  `json:"primaryType"`
+	Domain      map[string]any          `json:"domain"`
+	Message     map[string]any          `json:"message"`
+}
+
+type TypedField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+const domainType = "EIP712Domain"
+
+// UnmarshalJSON keeps numbers as json.Number, decoding them as float64 would
+// round integers above 2^53 before they are signed.
+func (r *TypedData) UnmarshalJSON(b []byte) error {
+	type typedData TypedData
+	var data typedData
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err := dec.Decode(&data)
+	if err != nil {
+		return err
+	}
+	*r = TypedData(data)
+	return nil
+}
+
+// Hash returns the digest that gets signed:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+func (r *TypedData) Hash() ([]byte, error) {
+	domain, err := r.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := r.HashStruct(r.PrimaryType, r.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return keccak256([]byte{0x19, 0x01}, domain, msg), nil
+}
+
+func (r *TypedData) DomainSeparator() ([]byte, error) {
+	if _, ok := r.Types[domainType]; !ok {
+		return nil, fmt.Errorf("typed data: types are missing %s", domainType)
+	}
+	return r.HashStruct(domainType, r.Domain)
+}
+
+func (r *TypedData) HashStruct(typ string, data map[string]any) ([]byte, error) {
+	encoded, err := r.encodeData(typ, data)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256(encoded), nil
+}
+
+func (r *TypedData) TypeHash(typ string) ([]byte, error) {
+	encoded, err := r.EncodeType(typ)
+	if err != nil {
+		return nil, err
+	}
+	return keccak256([]byte(encoded)), nil
+}
+
+// EncodeType returns the type followed by all types it references, sorted
+// by name, e.g. "Mail(Person from,string contents)Person(string name)".
+func (r *TypedData) EncodeType(typ string) (string, error) {
+	deps := map[string]bool{}
+	err := r.dependencies(typ, deps)
+	if err != nil {
+		return "", err
+	}
+	delete(deps, typ)
+
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+	slices.Sort(sorted)
+
+	var b strings.Builder
+	for _, t := range append([]string{typ}, sorted...) {
+		b.WriteString(t)
+		b.WriteByte('(')
+		for i, f := range r.Types[t] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type)
+			b.WriteByte(' ')
+			b.WriteString(f.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+func (r *TypedData) dependencies(typ string, found map[string]bool) error {
+	if found[typ] {
+		return nil
+	}
+	fields, ok := r.Types[typ]
+	if !ok {
+		return fmt.Errorf("typed data: unknown type %s", typ)
+	}
+	found[typ] = true
+
+	for _, f := range fields {
+		base := f.Type
+		if i := strings.IndexByte(base, '['); i >= 0 {
+			base = base[:i]
+		}
+		if _, ok := r.Types[base]; ok {
+			err := r.dependencies(base, found)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *TypedData) encodeData(typ string, data map[string]any) ([]byte, error) {
+	typeHash, err := r.TypeHash(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(typeHash)
+	for _, f := range r.Types[typ] {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("typed data: %s is missing field %s", typ, f.Name)
+		}
+
+		encoded, err := r.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("typed data: %s.%s: %w", typ, f.Name, err)
+		}
+		buf.Write(encoded)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeValue returns the 32 byte encoding of a single field.
+func (r *TypedData) encodeValue(typ string, v any) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		elemType := typ[:strings.LastIndexByte(typ, '[')]
+		size := typ[len(elemType)+1 : len(typ)-1]
+
+		items, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %T", v)
+		}
+		if size != "" && size != strconv.Itoa(len(items)) {
+			return nil, fmt.Errorf("expected %s items, got %d", size, len(items))
+		}
+
+		var buf bytes.Buffer
+		for i, item := range items {
+			encoded, err := r.encodeValue(elemType, item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			buf.Write(encoded)
+		}
+		return keccak256(buf.Bytes()), nil
+	}
+
+	if _, ok := r.Types[typ]; ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected struct, got %T", v)
+		}
+		return r.HashStruct(typ, m)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", v)
+		}
+		return keccak256([]byte(s)), nil
+
+	case typ == "bytes":
+		b, err := typedBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return keccak256(b), nil
+
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", v)
+		}
+		out := make([]byte, 32)
+		if b {
+			out[31] = 1
+		}
+		return out, nil
+
+	case typ == "address":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected address string, got %T", v)
+		}
+		addr, err := tronaddr.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, 32)
+		copy(out[12:], addr.EVMBytes())
+		return out, nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type %s", typ)
+		}
+		b, err := typedBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > size {
+			return nil, fmt.Errorf("expected at most %d bytes, got %d", size, len(b))
+		}
+		out := make([]byte, 32)
+		copy(out, b)
+		return out, nil
+
+	case typ == "trcToken", strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		t, err := abi.ParseType(typ)
+		if err != nil || t.Kind != abi.UintKind && t.Kind != abi.IntKind {
+			return nil, fmt.Errorf("invalid type %s", typ)
+		}
+		n, err := typedInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return abi.Encode([]abi.Argument{{Type: t}}, n)
+	}
+
+	return nil, fmt.Errorf("unknown type %s", typ)
+}
+
+func typedInt(v any) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		// larger floats may have been rounded already, pass those as
+		// strings or json.Number
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("%v is not an exact integer", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return typedInt(string(v))
+	case string:
+		n, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("%q is not an integer", v)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", v)
+}
+
+func typedBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		b, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not hex", v)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("expected bytes, got %T", v)
+}
+
+// SignTypedData signs the TIP-712 hash of td. The result is 0x prefixed hex
+// in the [R || S || V] format where V is 27 or 28.
+func SignTypedData(privKey *secp256k1.PrivateKey, td *TypedData) (string, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := Sign(hash, privKey)
+	if err != nil {
+		return "", err
+	}
+	sig[64] += 27
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+// VerifyTypedData checks that sig was produced over td by the key of addr.
+func VerifyTypedData(addr string, td *TypedData, sig string) error {
+	want, err := tronaddr.Parse(addr)
+	if err != nil {
+		return err
+	}
+
+	hash, err := td.Hash()
+	if err != nil {
+		return err
+	}
+
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+	if err != nil {
+		return fmt.Errorf("%w: invalid hex", ErrInvalidSignature)
+	}
+
+	got, err := Recover(hash, sigBytes)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: signed by %s", ErrInvalidSignature, got)
+	}
+
+	return nil
+}
+
+func keccak256(data ...[]byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hasher.Write(d)
+	}
+	return hasher.Sum(nil)
+}
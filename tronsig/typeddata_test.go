@@ -0,0 +1,188 @@
+package tronsig
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Example from the EIP-712 specification.
+const mail = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+// Same as above with nested arrays, from eth-sig-util's signTypedData v4.
+const mailV4 = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallets", "type": "address[]"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person[]"},
+			{"name": "contents", "type": "string"}
+		],
+		"Group": [
+			{"name": "name", "type": "string"},
+			{"name": "members", "type": "Person[]"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {
+			"name": "Cow",
+			"wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"]
+		},
+		"to": [{
+			"name": "Bob",
+			"wallets": [
+				"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+				"0xB0BdaBea57B0BDABeA57b0bdABEA57b0BDabEa57",
+				"0xB0B0b0b0b0b0B000000000000000000000000000"
+			]
+		}],
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedDataHash(t *testing.T) {
+	t.Parallel()
+
+	var td TypedData
+	err := json.Unmarshal([]byte(mail), &td)
+	assert.NoError(t, err)
+
+	encoded, err := td.EncodeType("Mail")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encoded)
+
+	typeHash, err := td.TypeHash("Mail")
+	assert.NoError(t, err)
+	assert.Equal(t, "a0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2", hex.EncodeToString(typeHash))
+
+	msgHash, err := td.HashStruct("Mail", td.Message)
+	assert.NoError(t, err)
+	assert.Equal(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToString(msgHash))
+
+	domain, err := td.DomainSeparator()
+	assert.NoError(t, err)
+	assert.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domain))
+
+	hash, err := td.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+}
+
+func TestTypedDataHashArrays(t *testing.T) {
+	t.Parallel()
+
+	var td TypedData
+	err := json.Unmarshal([]byte(mailV4), &td)
+	assert.NoError(t, err)
+
+	encoded, err := td.EncodeType("Group")
+	assert.NoError(t, err)
+	assert.Equal(t, "Group(string name,Person[] members)Person(string name,address[] wallets)", encoded)
+
+	hash, err := td.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "a85c2e2b118698e88db68a8105b794a8cc7cec074e89ef991cb4f5f533819cc2", hex.EncodeToString(hash))
+}
+
+func TestSignTypedData(t *testing.T) {
+	t.Parallel()
+
+	var td TypedData
+	err := json.Unmarshal([]byte(mail), &td)
+	assert.NoError(t, err)
+
+	privKey := secp256k1.PrivKeyFromBytes(keccak256([]byte("cow")))
+	sig, err := SignTypedData(privKey, &td)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x"+
+		"4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+
+		"1c", sig)
+
+	assert.NoError(t, VerifyTypedData("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", &td, sig))
+
+	td.Message["contents"] = "Hello, Alice!"
+	assert.ErrorIs(t, VerifyTypedData("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", &td, sig), ErrInvalidSignature)
+}
+
+func TestTypedDataInts(t *testing.T) {
+	t.Parallel()
+
+	td := TypedData{Types: map[string][]TypedField{}}
+
+	encoded, err := td.encodeValue("int8", float64(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", hex.EncodeToString(encoded))
+
+	_, err = td.encodeValue("int8", "128")
+	assert.Error(t, err)
+
+	encoded, err = td.encodeValue("uint256", "0xff")
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0xff), encoded[31])
+
+	_, err = td.encodeValue("uint8", "256")
+	assert.Error(t, err)
+
+	// floats above 2^53 may already be rounded
+	_, err = td.encodeValue("uint256", float64(1<<60))
+	assert.ErrorContains(t, err, "not an exact integer")
+
+	// json numbers are decoded exactly
+	err = json.Unmarshal([]byte(`{"domain": {"chainId": 9007199254740993}}`), &td)
+	assert.NoError(t, err)
+	encoded, err = td.encodeValue("uint256", td.Domain["chainId"])
+	assert.NoError(t, err)
+	want, _ := new(big.Int).SetString("9007199254740993", 10)
+	assert.Equal(t, want, new(big.Int).SetBytes(encoded))
+}
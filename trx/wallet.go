@@ -1,28 +1,25 @@
 package trx
 
 import (
-	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"fmt"
+	"iter"
 
-	"github.com/btcsuite/btcutil/base58"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
-	decred_ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
-	"github.com/joshuayildiz/wallet/chain"
 	"github.com/joshuayildiz/wallet/chain/trongrid"
-	"golang.org/x/crypto/sha3"
+	"github.com/joshuayildiz/wallet/tronaddr"
+	"github.com/joshuayildiz/wallet/tronsig"
+	"github.com/joshuayildiz/wallet/txevent"
 )
 
 type Wallet struct {
 	privKey  *secp256k1.PrivateKey
-	trongrid *trongrid.Client
+	trongrid trongrid.Backend
 }
 
-func New(trongrid *trongrid.Client) (*Wallet, error) {
+func New(trongrid trongrid.Backend) (*Wallet, error) {
 	privKey, err := secp256k1.GeneratePrivateKey()
 	if err != nil {
 		return nil, fmt.Errorf("trx.New: %w", err)
@@ -35,7 +32,7 @@ func New(trongrid *trongrid.Client) (*Wallet, error) {
 	return &self, nil
 }
 
-func NewWithPrivKeyHex(trongrid *trongrid.Client, privKeyHex string) (*Wallet, error) {
+func NewWithPrivKeyHex(trongrid trongrid.Backend, privKeyHex string) (*Wallet, error) {
 	privKeyBytes, err := hex.DecodeString(privKeyHex)
 	if err != nil {
 		return nil, fmt.Errorf("privkeyhex is invalid hex")
@@ -55,33 +52,7 @@ func (r *Wallet) PrivKeyHex() string {
 }
 
 func (r *Wallet) Addr() string {
-	pubKey := r.privKey.PubKey().SerializeUncompressed()
-
-	hasher := sha3.NewLegacyKeccak256()
-	hasher.Write(pubKey[1:])
-	keccak256 := hasher.Sum(nil)
-
-	last20 := keccak256[len(keccak256)-20:]
-
-	var networkedBuf bytes.Buffer
-	switch r.trongrid.Net {
-	case chain.Mainnet:
-		networkedBuf.WriteByte(0x41)
-	case chain.Testnet:
-		networkedBuf.WriteByte(0x41) // apparently shasta also uses Mainnet -_-
-	}
-	networkedBuf.Write(last20)
-
-	networked := networkedBuf.Bytes()
-
-	first := sha256.Sum256(networked)
-	second := sha256.Sum256(first[:])
-	checksum := second[:4]
-
-	both := append(networked, checksum...)
-	encoded := base58.Encode(both)
-
-	return encoded
+	return tronaddr.FromPubKey(r.privKey.PubKey()).String()
 }
 
 func (r *Wallet) Balance(ctx context.Context) (uint, error) {
@@ -92,23 +63,53 @@ func (r *Wallet) Balance(ctx context.Context) (uint, error) {
 	return balance, nil
 }
 
+// History yields the trx transfers of the wallet, newest first.
+func (r *Wallet) History(ctx context.Context, q trongrid.HistoryQuery) iter.Seq2[txevent.E, error] {
+	src, ok := r.trongrid.(trongrid.HistorySource)
+	if !ok {
+		return func(yield func(txevent.E, error) bool) {
+			yield(txevent.E{}, fmt.Errorf("backend %T does not support account history", r.trongrid))
+		}
+	}
+	return src.History(ctx, r.Addr(), q)
+}
+
 func (r *Wallet) Send(ctx context.Context, to string, amt uint) (string, error) {
-	tx, err := r.trongrid.CreateTx(ctx, r.Addr(), to, amt)
+	toAddr, err := tronaddr.Parse(to)
 	if err != nil {
-		return "", fmt.Errorf("creating transaction: %w", err)
+		return "", &trongrid.NotBroadcastError{Err: fmt.Errorf("invalid recipient: %w", err)}
 	}
 
-	rawDataBytes, err := hex.DecodeString(tx.RawDataHex)
+	tx, err := r.trongrid.CreateTxWithPermission(ctx, r.Addr(), toAddr.String(), amt, 0)
 	if err != nil {
-		return "", fmt.Errorf("raw data hex is invalid: %w", err)
+		return "", &trongrid.NotBroadcastError{Err: fmt.Errorf("creating transaction: %w", err)}
 	}
 
-	sig, err := r.sign(rawDataBytes)
+	err = r.SignTx(tx)
 	if err != nil {
-		return "", fmt.Errorf("signing raw data: %w", err)
+		return "", &trongrid.NotBroadcastError{Err: err}
 	}
 
-	tx.Signature = append(tx.Signature, sig)
+	hash, err := r.trongrid.Broadcast(ctx, *tx)
+	if err != nil {
+		return "", fmt.Errorf("broadcasting tx: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Trigger calls a contract from the wallet and returns the transaction hash.
+func (r *Wallet) Trigger(ctx context.Context, t trongrid.Trigger) (string, error) {
+	t.From = r.Addr()
+	tx, err := r.trongrid.TriggerContract(ctx, t)
+	if err != nil {
+		return "", &trongrid.NotBroadcastError{Err: err}
+	}
+
+	err = r.SignTx(tx)
+	if err != nil {
+		return "", &trongrid.NotBroadcastError{Err: err}
+	}
 
 	hash, err := r.trongrid.Broadcast(ctx, *tx)
 	if err != nil {
@@ -118,42 +119,42 @@ func (r *Wallet) Send(ctx context.Context, to string, amt uint) (string, error)
 	return hash, nil
 }
 
+// SignTx appends the signature of the wallet to tx. Transactions of multi
+// signature accounts get signed by every party in turn.
+func (r *Wallet) SignTx(tx *trongrid.Tx) error {
+	rawDataBytes, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil {
+		return fmt.Errorf("raw data hex is invalid: %w", err)
+	}
+
+	sig, err := r.sign(rawDataBytes)
+	if err != nil {
+		return fmt.Errorf("signing raw data: %w", err)
+	}
+
+	tx.Signature = append(tx.Signature, sig)
+	return nil
+}
+
+// SignMessage signs msg like tronweb's signMessageV2, see
+// tronsig.VerifyMessage for checking the signature.
+func (r *Wallet) SignMessage(msg []byte) (string, error) {
+	return tronsig.SignMessage(r.privKey, msg)
+}
+
+// SignTypedData signs TIP-712 structured data, see tronsig.VerifyTypedData
+// for checking the signature.
+func (r *Wallet) SignTypedData(td *tronsig.TypedData) (string, error) {
+	return tronsig.SignTypedData(r.privKey, td)
+}
+
 func (r *Wallet) sign(data []byte) (string, error) {
 	hash := sha256.Sum256(data)
 
-	sig, err := sign(hash[:], r.privKey.ToECDSA())
+	sig, err := tronsig.Sign(hash[:], r.privKey)
 	if err != nil {
 		return "", err
 	}
 
 	return hex.EncodeToString(sig), nil
 }
-
-// Sign calculates an ECDSA signature.
-//
-// This function is susceptible to chosen plaintext attacks that can leak
-// information about the private key that is used for signing. Callers must
-// be aware that the given hash cannot be chosen by an adversary. Common
-// solution is to hash any input before calculating the signature.
-//
-// The produced signature is in the [R || S || V] format where V is 0 or 1.
-func sign(hash []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
-	const DigestLength = 32
-	const RecoveryIDOffset = 64
-
-	if len(hash) != DigestLength {
-		return nil, fmt.Errorf("hash is required to be exactly %d bytes (%d)", DigestLength, len(hash))
-	}
-	// ecdsa.PrivateKey -> secp256k1.PrivateKey
-	var priv secp256k1.PrivateKey
-	if overflow := priv.Key.SetByteSlice(prv.D.Bytes()); overflow || priv.Key.IsZero() {
-		return nil, errors.New("invalid private key")
-	}
-	defer priv.Zero()
-	sig := decred_ecdsa.SignCompact(&priv, hash, false) // ref uncompressed pubkey
-	// Convert to Ethereum signature format with 'recovery id' v at the end.
-	v := sig[0] - 27
-	copy(sig, sig[1:])
-	sig[RecoveryIDOffset] = v
-	return sig, nil
-}
@@ -0,0 +1,45 @@
+package txevent
+
+import "time"
+
+const (
+	// CloudEventType is the type attribute of all cloud events carrying E.
+	CloudEventType = "com.github.joshuayildiz.wallet.txevent.v1"
+
+	// CloudEventSchema is the dataschema attribute, see JSONSchema.
+	CloudEventSchema = "https://github.com/joshuayildiz/wallet/blob/main/txevent/schema.json"
+)
+
+// CloudEvent is a CloudEvents 1.0 envelope in structured json mode.
+type CloudEvent struct {
+	SpecVersion     string     `json:"specversion"`
+	ID              string     `json:"id"`
+	Source          string     `json:"source"`
+	Type            string     `json:"type"`
+	Subject         string     `json:"subject,omitempty"`
+	Time            *time.Time `json:"time,omitempty"`
+	DataContentType string     `json:"datacontenttype"`
+	DataSchema      string     `json:"dataschema"`
+	Data            E          `json:"data"`
+}
+
+// CloudEvent wraps the event in an envelope. Source identifies the producer,
+// e.g. "/watcher/mainnet", the event id is unique within it. It is the
+// DeliveryID, so consumers deduplicating by id still see every state.
+func (r E) CloudEvent(source string) CloudEvent {
+	ce := CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              r.DeliveryID(),
+		Source:          source,
+		Type:            CloudEventType,
+		Subject:         r.Hash,
+		DataContentType: "application/json",
+		DataSchema:      CloudEventSchema,
+		Data:            r,
+	}
+	if !r.Timestamp.IsZero() {
+		t := r.Timestamp
+		ce.Time = &t
+	}
+	return ce
+}
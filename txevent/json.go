@@ -0,0 +1,133 @@
+package txevent
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// SchemaVersion of the json encoding. It is bumped on incompatible changes,
+// added fields keep the version.
+const SchemaVersion = 1
+
+// JSONSchema describes the json encoding of E.
+//
+//go:embed schema.json
+var JSONSchema []byte
+
+// jsonE is the canonical json form. Amounts are decimal strings so consumers
+// without 64 bit integers do not lose precision.
+type jsonE struct {
+	SchemaVersion int        `json:"schema_version"`
+	ID            string     `json:"id"`
+	Block         uint       `json:"block"`
+	BlockHash     string     `json:"block_hash"`
+	Timestamp     *time.Time `json:"timestamp,omitempty"`
+	TxHash        string     `json:"tx_hash"`
+	LogIndex      int        `json:"log_index"`
+	Currency      Currency   `json:"currency"`
+	Contract      string     `json:"contract"`
+	Sender        string     `json:"sender"`
+	Receiver      string     `json:"receiver"`
+	Amount        string     `json:"amount"`
+	Status        Status     `json:"status"`
+	Fee           string     `json:"fee"`
+	EnergyFee     string     `json:"energy_fee"`
+	NetFee        string     `json:"net_fee"`
+	EnergyUsed    int        `json:"energy_used"`
+	Memo          string     `json:"memo"`
+	State         State      `json:"state,omitempty"`
+	Direction     Direction  `json:"direction,omitempty"`
+}
+
+func (r E) MarshalJSON() ([]byte, error) {
+	data := jsonE{
+		SchemaVersion: SchemaVersion,
+		ID:            r.ID(),
+		Block:         r.Block,
+		BlockHash:     r.BlockHash,
+		TxHash:        r.Hash,
+		LogIndex:      r.LogIndex,
+		Currency:      r.Currency,
+		Contract:      r.Contract,
+		Sender:        r.Sender,
+		Receiver:      r.Receiver,
+		Amount:        r.AmountBig().String(),
+		Status:        r.Status,
+		Fee:           strconv.Itoa(r.Fee),
+		EnergyFee:     strconv.Itoa(r.EnergyFee),
+		NetFee:        strconv.Itoa(r.NetFee),
+		EnergyUsed:    r.EnergyUsed,
+		Memo:          r.Memo,
+		State:         r.State,
+		Direction:     r.Direction,
+	}
+	// pending events have no block time yet
+	if !r.Timestamp.IsZero() {
+		data.Timestamp = &r.Timestamp
+	}
+	return json.Marshal(data)
+}
+
+func (r *E) UnmarshalJSON(b []byte) error {
+	var data jsonE
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+	if data.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("txevent: schema version %d is newer than %d", data.SchemaVersion, SchemaVersion)
+	}
+
+	r.Amount, r.BigAmount = 0, nil
+	if data.Amount != "" {
+		amt, ok := new(big.Int).SetString(data.Amount, 10)
+		if !ok {
+			return fmt.Errorf("txevent: invalid amount %q", data.Amount)
+		}
+		r.SetAmount(amt)
+	}
+
+	fees := []struct {
+		name string
+		in   string
+		out  *int
+	}{
+		{"fee", data.Fee, &r.Fee},
+		{"energy_fee", data.EnergyFee, &r.EnergyFee},
+		{"net_fee", data.NetFee, &r.NetFee},
+	}
+	for _, a := range fees {
+		if a.in == "" {
+			*a.out = 0
+			continue
+		}
+		n, err := strconv.Atoi(a.in)
+		if err != nil {
+			return fmt.Errorf("txevent: invalid %s %q", a.name, a.in)
+		}
+		*a.out = n
+	}
+
+	r.Block = data.Block
+	r.BlockHash = data.BlockHash
+	r.Timestamp = time.Time{}
+	if data.Timestamp != nil {
+		r.Timestamp = *data.Timestamp
+	}
+	r.Hash = data.TxHash
+	r.LogIndex = data.LogIndex
+	r.Currency = data.Currency
+	r.Contract = data.Contract
+	r.Sender = data.Sender
+	r.Receiver = data.Receiver
+	r.Status = data.Status
+	r.EnergyUsed = data.EnergyUsed
+	r.Memo = data.Memo
+	r.State = data.State
+	r.Direction = data.Direction
+	return nil
+}
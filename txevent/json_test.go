@@ -0,0 +1,100 @@
+package txevent
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	e := E{
+		Block:     100,
+		BlockHash: "00ff",
+		Timestamp: time.UnixMilli(1700000000000).UTC(),
+		Hash:      "abcd",
+		LogIndex:  2,
+		Currency:  TRON_USDT,
+		Contract:  "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Sender:    "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC",
+		Receiver:  "TDvSsdrNM5eeXNL3czpa6AxLDHZA9nwe9K",
+		Amount:    9007199254740993, // not representable as float64
+		Status:    StatusSuccess,
+		Fee:       345,
+		EnergyFee: 300,
+		NetFee:    45,
+		Memo:      "order 1",
+	}
+
+	b, err := json.Marshal(e)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schema_version": 1,
+		"id": "abcd:2",
+		"block": 100,
+		"block_hash": "00ff",
+		"timestamp": "2023-11-14T22:13:20Z",
+		"tx_hash": "abcd",
+		"log_index": 2,
+		"currency": "TRON_USDT",
+		"contract": "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		"sender": "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC",
+		"receiver": "TDvSsdrNM5eeXNL3czpa6AxLDHZA9nwe9K",
+		"amount": "9007199254740993",
+		"status": "SUCCESS",
+		"fee": "345",
+		"energy_fee": "300",
+		"net_fee": "45",
+		"energy_used": 0,
+		"memo": "order 1"
+	}`, string(b))
+
+	var decoded E
+	err = json.Unmarshal(b, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, e, decoded)
+
+	err = json.Unmarshal([]byte(`{"schema_version": 2}`), &decoded)
+	assert.Error(t, err)
+
+	// amounts beyond an int keep every digit
+	large := E{Hash: "abcd"}
+	large.SetAmount(new(big.Int).Lsh(big.NewInt(1), 64))
+	b, err = json.Marshal(large)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"amount":"18446744073709551616"`)
+	decoded = E{}
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, large, decoded)
+
+	// pending events have no timestamp
+	b, err = json.Marshal(E{Hash: "abcd"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "timestamp")
+}
+
+func TestCloudEvent(t *testing.T) {
+	t.Parallel()
+
+	e := E{Hash: "abcd", LogIndex: 1, State: StateConfirmed, Timestamp: time.UnixMilli(1700000000000).UTC()}
+
+	b, err := json.Marshal(e.CloudEvent("/watcher/mainnet"))
+	assert.NoError(t, err)
+
+	var ce map[string]any
+	err = json.Unmarshal(b, &ce)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0", ce["specversion"])
+	assert.Equal(t, "abcd:1:CONFIRMED", ce["id"])
+	assert.Equal(t, "/watcher/mainnet", ce["source"])
+	assert.Equal(t, CloudEventType, ce["type"])
+	assert.Equal(t, "2023-11-14T22:13:20Z", ce["time"])
+	assert.Equal(t, "abcd", ce["data"].(map[string]any)["tx_hash"])
+
+	var schema map[string]any
+	assert.NoError(t, json.Unmarshal(JSONSchema, &schema))
+}
@@ -1,13 +1,83 @@
 package txevent
 
+import (
+	"math/big"
+	"strconv"
+	"time"
+)
+
 type E struct {
-	Block    uint
-	Hash     string
+	Block     uint
+	BlockHash string
+	Timestamp time.Time
+	Hash      string
+
+	// Position of the log within the transaction, a transaction can carry
+	// several token transfers. Zero for trx transfers.
+	LogIndex int
+
 	Currency Currency
+
+	// Token contract, empty for trx.
+	Contract string
+
 	Sender   string
 	Receiver string
-	Amount   int
-	Fee      int
+
+	// Token amounts that do not fit an int are held by BigAmount instead,
+	// Amount is zero then. See SetAmount.
+	Amount    int
+	BigAmount *big.Int
+
+	Status Status
+
+	// Total fee burnt in sun and how it splits up.
+	Fee        int
+	EnergyFee  int
+	NetFee     int
+	EnergyUsed int
+
+	// Data field of the transaction, often used as memo.
+	Memo string
+
+	// Events of the same transfer share the ID but can arrive in several
+	// states, e.g. pending and later confirmed.
+	State State
+
+	// Only set by watchers of an address set. Self if both sender and
+	// receiver are watched.
+	Direction Direction
+}
+
+// SetAmount sets Amount, or BigAmount if amt does not fit an int.
+func (r *E) SetAmount(amt *big.Int) {
+	if amt.IsInt64() && int64(int(amt.Int64())) == amt.Int64() {
+		r.Amount, r.BigAmount = int(amt.Int64()), nil
+		return
+	}
+	r.Amount, r.BigAmount = 0, new(big.Int).Set(amt)
+}
+
+// AmountBig returns the amount of either field.
+func (r E) AmountBig() *big.Int {
+	if r.BigAmount != nil {
+		return new(big.Int).Set(r.BigAmount)
+	}
+	return big.NewInt(int64(r.Amount))
+}
+
+// ID uniquely identifies the event across all transactions.
+func (r E) ID() string {
+	return r.Hash + ":" + strconv.Itoa(r.LogIndex)
+}
+
+// DeliveryID is ID plus the state, it tells apart the deliveries of the same
+// transfer, e.g. pending and later confirmed.
+func (r E) DeliveryID() string {
+	if r.State == "" {
+		return r.ID()
+	}
+	return r.ID() + ":" + string(r.State)
 }
 
 type Currency string
@@ -15,4 +85,72 @@ type Currency string
 const (
 	TRX       Currency = "TRX"
 	TRON_USDT Currency = "TRON_USDT"
+
+	// Any other trc20 token, see Contract.
+	TRC20 Currency = "TRC20"
+)
+
+// Status is the result of the contract execution as reported by the node.
+type Status string
+
+const (
+	StatusSuccess            Status = "SUCCESS"
+	StatusRevert             Status = "REVERT"
+	StatusOutOfEnergy        Status = "OUT_OF_ENERGY"
+	StatusOutOfTime          Status = "OUT_OF_TIME"
+	StatusOutOfMemory        Status = "OUT_OF_MEMORY"
+	StatusTransferFailed     Status = "TRANSFER_FAILED"
+	StatusBadJumpDestination Status = "BAD_JUMP_DESTINATION"
+	StatusIllegalOperation   Status = "ILLEGAL_OPERATION"
+	StatusStackOverflow      Status = "STACK_OVERFLOW"
+	StatusStackTooSmall      Status = "STACK_TOO_SMALL"
+	StatusStackTooLarge      Status = "STACK_TOO_LARGE"
+	StatusJVMStackOverflow   Status = "JVM_STACK_OVER_FLOW"
+	StatusPrecompiledFailed  Status = "PRECOMPILED_CONTRACT"
+	StatusInvalidCode        Status = "INVALID_CODE"
+	StatusUnknown            Status = "UNKNOWN"
+)
+
+func (r Status) Success() bool {
+	return r == StatusSuccess
+}
+
+// Log is a decoded contract event.
+type Log struct {
+	Block    uint
+	Hash     string
+	LogIndex int
+	Contract string
+	Event    string
+
+	// Arguments by name, both indexed and non-indexed ones.
+	Args map[string]any
+}
+
+// State of the block the transfer is in.
+type State string
+
+const (
+	// In a block of the fullnode that is not solidified yet.
+	StatePending State = "PENDING"
+
+	// Solidified or deep enough, see trongrid.WithPending.
+	StateConfirmed State = "CONFIRMED"
+
+	// A pending transfer whose block got reorganized away without the
+	// transfer being included again.
+	StateDropped State = "DROPPED"
+
+	// A confirmed transfer whose block was orphaned by a reorg, consumers
+	// should undo it. If it is included again a new confirmed event follows.
+	StateReverted State = "REVERTED"
+)
+
+// Direction of a transfer relative to the watched addresses.
+type Direction string
+
+const (
+	DirectionIn   Direction = "IN"
+	DirectionOut  Direction = "OUT"
+	DirectionSelf Direction = "SELF"
 )
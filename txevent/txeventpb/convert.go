@@ -0,0 +1,98 @@
+// Package txeventpb is the protobuf form of txevent.E, generated from
+// txevent.proto.
+package txeventpb
+
+//go:generate protoc -I .. --go_out=. --go_opt=module=github.com/joshuayildiz/wallet/txevent/txeventpb txevent.proto
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/joshuayildiz/wallet/txevent"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FromEvent converts e to its protobuf form.
+func FromEvent(e txevent.E) *E {
+	self := &E{
+		SchemaVersion: txevent.SchemaVersion,
+		Id:            e.ID(),
+		Block:         uint64(e.Block),
+		BlockHash:     e.BlockHash,
+		TxHash:        e.Hash,
+		LogIndex:      uint32(e.LogIndex),
+		Currency:      string(e.Currency),
+		Contract:      e.Contract,
+		Sender:        e.Sender,
+		Receiver:      e.Receiver,
+		Amount:        e.AmountBig().String(),
+		Status:        string(e.Status),
+		Fee:           strconv.Itoa(e.Fee),
+		EnergyFee:     strconv.Itoa(e.EnergyFee),
+		NetFee:        strconv.Itoa(e.NetFee),
+		EnergyUsed:    uint64(e.EnergyUsed),
+		Memo:          e.Memo,
+		Direction:     string(e.Direction),
+		State:         string(e.State),
+	}
+	if !e.Timestamp.IsZero() {
+		self.Timestamp = timestamppb.New(e.Timestamp)
+	}
+	return self
+}
+
+// Event converts the message back to a txevent.E.
+func (x *E) Event() (txevent.E, error) {
+	if x.GetSchemaVersion() > txevent.SchemaVersion {
+		return txevent.E{}, fmt.Errorf("txeventpb: schema version %d is newer than %d", x.GetSchemaVersion(), txevent.SchemaVersion)
+	}
+
+	e := txevent.E{
+		Block:      uint(x.GetBlock()),
+		BlockHash:  x.GetBlockHash(),
+		Hash:       x.GetTxHash(),
+		LogIndex:   int(x.GetLogIndex()),
+		Currency:   txevent.Currency(x.GetCurrency()),
+		Contract:   x.GetContract(),
+		Sender:     x.GetSender(),
+		Receiver:   x.GetReceiver(),
+		Status:     txevent.Status(x.GetStatus()),
+		EnergyUsed: int(x.GetEnergyUsed()),
+		Memo:       x.GetMemo(),
+		Direction:  txevent.Direction(x.GetDirection()),
+		State:      txevent.State(x.GetState()),
+	}
+	if x.GetTimestamp() != nil {
+		e.Timestamp = x.GetTimestamp().AsTime()
+	}
+
+	if x.GetAmount() != "" {
+		amt, ok := new(big.Int).SetString(x.GetAmount(), 10)
+		if !ok {
+			return txevent.E{}, fmt.Errorf("txeventpb: invalid amount %q", x.GetAmount())
+		}
+		e.SetAmount(amt)
+	}
+
+	fees := []struct {
+		name string
+		in   string
+		out  *int
+	}{
+		{"fee", x.GetFee(), &e.Fee},
+		{"energy_fee", x.GetEnergyFee(), &e.EnergyFee},
+		{"net_fee", x.GetNetFee(), &e.NetFee},
+	}
+	for _, a := range fees {
+		if a.in == "" {
+			continue
+		}
+		n, err := strconv.Atoi(a.in)
+		if err != nil {
+			return txevent.E{}, fmt.Errorf("txeventpb: invalid %s %q", a.name, a.in)
+		}
+		*a.out = n
+	}
+	return e, nil
+}
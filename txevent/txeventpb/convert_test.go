@@ -0,0 +1,51 @@
+package txeventpb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	e := txevent.E{
+		Block:     100,
+		BlockHash: "00ff",
+		Timestamp: time.UnixMilli(1700000000000).UTC(),
+		Hash:      "abcd",
+		LogIndex:  2,
+		Currency:  txevent.TRON_USDT,
+		Contract:  "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Sender:    "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC",
+		Receiver:  "TDvSsdrNM5eeXNL3czpa6AxLDHZA9nwe9K",
+		Amount:    9007199254740993,
+		Status:    txevent.StatusSuccess,
+		Fee:       345,
+		EnergyFee: 300,
+		NetFee:    45,
+		Memo:      "order 1",
+		State:     txevent.StateConfirmed,
+	}
+
+	b, err := proto.Marshal(FromEvent(e))
+	assert.NoError(t, err)
+
+	var m E
+	assert.NoError(t, proto.Unmarshal(b, &m))
+	assert.Equal(t, "abcd:2", m.GetId())
+	assert.Equal(t, "9007199254740993", m.GetAmount())
+
+	decoded, err := m.Event()
+	assert.NoError(t, err)
+	assert.Equal(t, e, decoded)
+
+	// pending events have no timestamp
+	assert.Nil(t, FromEvent(txevent.E{Hash: "abcd"}).GetTimestamp())
+
+	_, err = (&E{SchemaVersion: 2}).Event()
+	assert.Error(t, err)
+}
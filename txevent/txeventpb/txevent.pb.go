@@ -0,0 +1,314 @@
+// Protobuf form of txevent.E, field meanings match the json schema in
+// schema.json.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: txevent.proto
+
+package txeventpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type E struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Always 1 for this version of the message.
+	SchemaVersion uint32 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// tx_hash and log_index joined by a colon.
+	Id        string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Block     uint64                 `protobuf:"varint,3,opt,name=block,proto3" json:"block,omitempty"`
+	BlockHash string                 `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TxHash    string                 `protobuf:"bytes,6,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	LogIndex  uint32                 `protobuf:"varint,7,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	// "TRX", "TRON_USDT" or "TRC20" for other tokens, see contract.
+	Currency string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Token contract, empty for trx.
+	Contract string `protobuf:"bytes,9,opt,name=contract,proto3" json:"contract,omitempty"`
+	Sender   string `protobuf:"bytes,10,opt,name=sender,proto3" json:"sender,omitempty"`
+	Receiver string `protobuf:"bytes,11,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	// Decimal string in the smallest unit of the currency.
+	Amount string `protobuf:"bytes,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Contract result, e.g. "SUCCESS" or "REVERT".
+	Status string `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	// Decimal strings in sun.
+	Fee        string `protobuf:"bytes,14,opt,name=fee,proto3" json:"fee,omitempty"`
+	EnergyFee  string `protobuf:"bytes,15,opt,name=energy_fee,json=energyFee,proto3" json:"energy_fee,omitempty"`
+	NetFee     string `protobuf:"bytes,16,opt,name=net_fee,json=netFee,proto3" json:"net_fee,omitempty"`
+	EnergyUsed uint64 `protobuf:"varint,17,opt,name=energy_used,json=energyUsed,proto3" json:"energy_used,omitempty"`
+	Memo       string `protobuf:"bytes,18,opt,name=memo,proto3" json:"memo,omitempty"`
+	// "IN", "OUT" or "SELF" relative to the watched addresses, empty if the
+	// watcher does not watch an address set.
+	Direction string `protobuf:"bytes,19,opt,name=direction,proto3" json:"direction,omitempty"`
+	// "PENDING", "CONFIRMED", "DROPPED" or "REVERTED". Events of the same
+	// transfer share the id and differ in state.
+	State         string `protobuf:"bytes,20,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *E) Reset() {
+	*x = E{}
+	mi := &file_txevent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *E) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E) ProtoMessage() {}
+
+func (x *E) ProtoReflect() protoreflect.Message {
+	mi := &file_txevent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E.ProtoReflect.Descriptor instead.
+func (*E) Descriptor() ([]byte, []int) {
+	return file_txevent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *E) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *E) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *E) GetBlock() uint64 {
+	if x != nil {
+		return x.Block
+	}
+	return 0
+}
+
+func (x *E) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *E) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *E) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *E) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *E) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *E) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *E) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *E) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *E) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *E) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *E) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+func (x *E) GetEnergyFee() string {
+	if x != nil {
+		return x.EnergyFee
+	}
+	return ""
+}
+
+func (x *E) GetNetFee() string {
+	if x != nil {
+		return x.NetFee
+	}
+	return ""
+}
+
+func (x *E) GetEnergyUsed() uint64 {
+	if x != nil {
+		return x.EnergyUsed
+	}
+	return 0
+}
+
+func (x *E) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *E) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *E) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+var File_txevent_proto protoreflect.FileDescriptor
+
+const file_txevent_proto_rawDesc = "" +
+	"\n" +
+	"\rtxevent.proto\x12\x11wallet.txevent.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x04\n" +
+	"\x01E\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05block\x18\x03 \x01(\x04R\x05block\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x04 \x01(\tR\tblockHash\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
+	"\atx_hash\x18\x06 \x01(\tR\x06txHash\x12\x1b\n" +
+	"\tlog_index\x18\a \x01(\rR\blogIndex\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bcontract\x18\t \x01(\tR\bcontract\x12\x16\n" +
+	"\x06sender\x18\n" +
+	" \x01(\tR\x06sender\x12\x1a\n" +
+	"\breceiver\x18\v \x01(\tR\breceiver\x12\x16\n" +
+	"\x06amount\x18\f \x01(\tR\x06amount\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12\x10\n" +
+	"\x03fee\x18\x0e \x01(\tR\x03fee\x12\x1d\n" +
+	"\n" +
+	"energy_fee\x18\x0f \x01(\tR\tenergyFee\x12\x17\n" +
+	"\anet_fee\x18\x10 \x01(\tR\x06netFee\x12\x1f\n" +
+	"\venergy_used\x18\x11 \x01(\x04R\n" +
+	"energyUsed\x12\x12\n" +
+	"\x04memo\x18\x12 \x01(\tR\x04memo\x12\x1c\n" +
+	"\tdirection\x18\x13 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05state\x18\x14 \x01(\tR\x05stateB2Z0github.com/joshuayildiz/wallet/txevent/txeventpbb\x06proto3"
+
+var (
+	file_txevent_proto_rawDescOnce sync.Once
+	file_txevent_proto_rawDescData []byte
+)
+
+func file_txevent_proto_rawDescGZIP() []byte {
+	file_txevent_proto_rawDescOnce.Do(func() {
+		file_txevent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_txevent_proto_rawDesc), len(file_txevent_proto_rawDesc)))
+	})
+	return file_txevent_proto_rawDescData
+}
+
+var file_txevent_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_txevent_proto_goTypes = []any{
+	(*E)(nil),                     // 0: wallet.txevent.v1.E
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_txevent_proto_depIdxs = []int32{
+	1, // 0: wallet.txevent.v1.E.timestamp:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_txevent_proto_init() }
+func file_txevent_proto_init() {
+	if File_txevent_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_txevent_proto_rawDesc), len(file_txevent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_txevent_proto_goTypes,
+		DependencyIndexes: file_txevent_proto_depIdxs,
+		MessageInfos:      file_txevent_proto_msgTypes,
+	}.Build()
+	File_txevent_proto = out.File
+	file_txevent_proto_goTypes = nil
+	file_txevent_proto_depIdxs = nil
+}
@@ -0,0 +1,273 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/joshuayildiz/wallet/internal/atomicfile"
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+// Delivery is a single event on its way to a single endpoint.
+type Delivery struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Event       txevent.E `json:"event"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error"`
+}
+
+// Outbox durably stores deliveries. Put must not return before the delivery
+// is persisted and must ignore deliveries it already knows.
+type Outbox interface {
+	Put(d Delivery) error
+
+	// Due returns pending deliveries with NextAttempt before now.
+	Due(now time.Time) ([]Delivery, error)
+
+	// Update stores the new state of a pending delivery after a failed attempt.
+	Update(d Delivery) error
+
+	// Done removes a delivered delivery.
+	Done(id string) error
+
+	// Dead moves a delivery that ran out of attempts to the dead letters.
+	Dead(d Delivery) error
+
+	DeadLetters() ([]Delivery, error)
+}
+
+// MemOutbox keeps deliveries in memory. It does not survive restarts and is
+// meant for tests.
+type MemOutbox struct {
+	mu      sync.Mutex
+	pending map[string]Delivery
+	dead    map[string]Delivery
+}
+
+func NewMemOutbox() *MemOutbox {
+	return &MemOutbox{
+		pending: make(map[string]Delivery),
+		dead:    make(map[string]Delivery),
+	}
+}
+
+func (r *MemOutbox) Put(d Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pending[d.ID]; ok {
+		return nil
+	}
+	if _, ok := r.dead[d.ID]; ok {
+		return nil
+	}
+	r.pending[d.ID] = d
+	return nil
+}
+
+func (r *MemOutbox) Due(now time.Time) ([]Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []Delivery
+	for _, d := range r.pending {
+		if !d.NextAttempt.After(now) {
+			out = append(out, d)
+		}
+	}
+	sortDeliveries(out)
+	return out, nil
+}
+
+func (r *MemOutbox) Update(d Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending[d.ID] = d
+	return nil
+}
+
+func (r *MemOutbox) Done(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending, id)
+	return nil
+}
+
+func (r *MemOutbox) Dead(d Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending, d.ID)
+	r.dead[d.ID] = d
+	return nil
+}
+
+func (r *MemOutbox) DeadLetters() ([]Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Delivery, 0, len(r.dead))
+	for _, d := range r.dead {
+		out = append(out, d)
+	}
+	sortDeliveries(out)
+	return out, nil
+}
+
+// FileOutbox keeps one json file per delivery, pending ones in dir/pending
+// and dead letters in dir/dead.
+type FileOutbox struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileOutbox(dir string) (*FileOutbox, error) {
+	for _, sub := range []string{"pending", "dead"} {
+		err := os.MkdirAll(filepath.Join(dir, sub), 0o700)
+		if err != nil {
+			return nil, fmt.Errorf("webhook.NewFileOutbox: %w", err)
+		}
+	}
+
+	self := FileOutbox{dir: dir}
+	return &self, nil
+}
+
+func (r *FileOutbox) Put(d Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, sub := range []string{"pending", "dead"} {
+		_, err := os.Stat(r.path(sub, d.ID))
+		if err == nil {
+			return nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return r.write("pending", d)
+}
+
+func (r *FileOutbox) Due(now time.Time) ([]Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	all, err := r.read("pending")
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Delivery
+	for _, d := range all {
+		if !d.NextAttempt.After(now) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *FileOutbox) Update(d Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.write("pending", d)
+}
+
+func (r *FileOutbox) Done(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := os.Remove(r.path("pending", id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (r *FileOutbox) Dead(d Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.write("dead", d)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(r.path("pending", d.ID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (r *FileOutbox) DeadLetters() ([]Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read("dead")
+}
+
+func (r *FileOutbox) write(sub string, d Delivery) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("encoding delivery: %w", err)
+	}
+
+	return atomicfile.Write(r.path(sub, d.ID), b)
+}
+
+func (r *FileOutbox) read(sub string) ([]Delivery, error) {
+	paths, err := filepath.Glob(filepath.Join(r.dir, sub, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Delivery, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var d Delivery
+		err = json.Unmarshal(b, &d)
+		if err != nil {
+			return nil, fmt.Errorf("decoding delivery %s: %w", path, err)
+		}
+		out = append(out, d)
+	}
+	sortDeliveries(out)
+	return out, nil
+}
+
+func (r *FileOutbox) path(sub, id string) string {
+	return filepath.Join(r.dir, sub, id+".json")
+}
+
+// sortDeliveries orders by block so consumers mostly see events in order.
+func sortDeliveries(ds []Delivery) {
+	slices.SortFunc(ds, func(a, b Delivery) int {
+		if a.Event.Block != b.Event.Block {
+			if a.Event.Block < b.Event.Block {
+				return -1
+			}
+			return 1
+		}
+		if a.ID < b.ID {
+			return -1
+		} else if a.ID > b.ID {
+			return 1
+		}
+		return 0
+	})
+}
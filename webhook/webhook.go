@@ -0,0 +1,286 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshuayildiz/wallet/txevent"
+)
+
+const (
+	SignatureHeader = "X-Wallet-Signature"
+	TimestampHeader = "X-Wallet-Timestamp"
+
+	// Carries txevent.E.DeliveryID, which differs between the states of a
+	// transfer.
+	EventIDHeader = "X-Wallet-Event-Id"
+)
+
+// Endpoint receives events matching its filters. Empty filters match all.
+type Endpoint struct {
+	URL    string
+	Secret []byte
+
+	// Events are sent if the sender or the receiver is one of these.
+	Addresses []string
+
+	Currencies []txevent.Currency
+}
+
+func (r *Endpoint) matches(e txevent.E) bool {
+	if len(r.Currencies) > 0 && !slices.Contains(r.Currencies, e.Currency) {
+		return false
+	}
+	if len(r.Addresses) > 0 && !slices.Contains(r.Addresses, e.Sender) && !slices.Contains(r.Addresses, e.Receiver) {
+		return false
+	}
+	return true
+}
+
+// Sink posts watcher events to endpoints. Events are first recorded in the
+// outbox by Record, which is meant to be passed to trongrid.WithHandler, and
+// then delivered by Run.
+type Sink struct {
+	endpoints []Endpoint
+	outbox    Outbox
+	source    string
+
+	client      *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	interval    time.Duration
+	now         func() time.Time
+}
+
+type Option func(*Sink)
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Sink) {
+		r.client = client
+	}
+}
+
+// WithRetries sets how often a delivery is attempted before it becomes a
+// dead letter and the backoff between attempts, which doubles every time.
+func WithRetries(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(r *Sink) {
+		r.maxAttempts = maxAttempts
+		r.baseDelay = baseDelay
+		r.maxDelay = maxDelay
+	}
+}
+
+// WithInterval sets how often Run looks for due deliveries.
+func WithInterval(d time.Duration) Option {
+	return func(r *Sink) {
+		r.interval = d
+	}
+}
+
+// WithSource sets the source attribute of the cloud events that are posted.
+func WithSource(source string) Option {
+	return func(r *Sink) {
+		r.source = source
+	}
+}
+
+func New(outbox Outbox, endpoints []Endpoint, opts ...Option) *Sink {
+	self := &Sink{
+		endpoints:   endpoints,
+		outbox:      outbox,
+		source:      "/wallet/watcher",
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 10,
+		baseDelay:   time.Second,
+		maxDelay:    time.Hour,
+		interval:    time.Second,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(self)
+	}
+	return self
+}
+
+// Record stores a delivery of e for every matching endpoint.
+func (r *Sink) Record(ctx context.Context, e txevent.E) error {
+	for _, ep := range r.endpoints {
+		if !ep.matches(e) {
+			continue
+		}
+
+		err := r.outbox.Put(Delivery{
+			ID:          deliveryID(ep.URL, e),
+			URL:         ep.URL,
+			Event:       e,
+			NextAttempt: r.now(),
+		})
+		if err != nil {
+			return fmt.Errorf("recording %s for %s: %w", e.ID(), ep.URL, err)
+		}
+	}
+	return nil
+}
+
+// Run delivers due deliveries until ctx is done.
+func (r *Sink) Run(ctx context.Context) error {
+	for {
+		err := r.Flush(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// Flush attempts all due deliveries once. Only outbox errors are returned,
+// failed attempts are scheduled for a retry.
+func (r *Sink) Flush(ctx context.Context) error {
+	due, err := r.outbox.Due(r.now())
+	if err != nil {
+		return fmt.Errorf("loading due deliveries: %w", err)
+	}
+
+	for _, d := range due {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		ep, ok := r.endpoint(d.URL)
+		if !ok {
+			// endpoint was removed from the config
+			d.LastError = "endpoint not configured"
+			err = r.outbox.Dead(d)
+			if err != nil {
+				return fmt.Errorf("moving %s to dead letters: %w", d.ID, err)
+			}
+			continue
+		}
+
+		err := r.post(ctx, ep, d.Event)
+		if err == nil {
+			err = r.outbox.Done(d.ID)
+			if err != nil {
+				return fmt.Errorf("marking %s as done: %w", d.ID, err)
+			}
+			continue
+		}
+
+		d.Attempts++
+		d.LastError = err.Error()
+		if d.Attempts >= r.maxAttempts {
+			err = r.outbox.Dead(d)
+			if err != nil {
+				return fmt.Errorf("moving %s to dead letters: %w", d.ID, err)
+			}
+			continue
+		}
+
+		d.NextAttempt = r.now().Add(r.backoff(d.Attempts))
+		err = r.outbox.Update(d)
+		if err != nil {
+			return fmt.Errorf("updating %s: %w", d.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Sink) post(ctx context.Context, ep Endpoint, e txevent.E) error {
+	body, err := json.Marshal(e.CloudEvent(r.source))
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(r.now().Unix(), 10)
+	req.Header.Add("Content-Type", "application/cloudevents+json")
+	req.Header.Add(TimestampHeader, timestamp)
+	req.Header.Add(EventIDHeader, e.DeliveryID())
+	req.Header.Add(SignatureHeader, "sha256="+Sign(ep.Secret, timestamp, body))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *Sink) backoff(attempts int) time.Duration {
+	delay := r.baseDelay
+	for i := 1; i < attempts && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxDelay)
+}
+
+func (r *Sink) endpoint(url string) (Endpoint, bool) {
+	for _, ep := range r.endpoints {
+		if ep.URL == url {
+			return ep, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "timestamp.body".
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// Verify checks the signature headers of a received webhook. Requests older
+// than tolerance are rejected to prevent replays.
+func Verify(secret []byte, header http.Header, body []byte, tolerance time.Duration) error {
+	timestamp := header.Get(TimestampHeader)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	got := strings.TrimPrefix(header.Get(SignatureHeader), "sha256=")
+	want := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(got), []byte(want)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func deliveryID(url string, e txevent.E) string {
+	sum := sha256.Sum256([]byte(url + "\n" + e.ID() + "\n" + string(e.State)))
+	return hex.EncodeToString(sum[:16])
+}
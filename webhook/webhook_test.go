@@ -0,0 +1,211 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joshuayildiz/wallet/txevent"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	alice = "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC"
+	bob   = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+)
+
+type receiver struct {
+	mu     sync.Mutex
+	secret []byte
+	fail   int
+	bodies [][]byte
+	ids    []string
+	errs   []error
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fail > 0 {
+		r.fail--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	r.errs = append(r.errs, Verify(r.secret, req.Header, body, time.Minute))
+	r.bodies = append(r.bodies, body)
+	r.ids = append(r.ids, req.Header.Get(EventIDHeader))
+}
+
+func event(hash string, currency txevent.Currency, sender, receiver string) txevent.E {
+	return txevent.E{
+		Block:     10,
+		Hash:      hash,
+		Currency:  currency,
+		Sender:    sender,
+		Receiver:  receiver,
+		Amount:    1_000_000,
+		Status:    txevent.StatusSuccess,
+		Timestamp: time.Unix(1_700_000_000, 0).UTC(),
+	}
+}
+
+func TestSinkDelivers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	secret := []byte("secret")
+
+	recv := &receiver{secret: secret}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	outbox := NewMemOutbox()
+	sink := New(outbox, []Endpoint{{
+		URL:        srv.URL,
+		Secret:     secret,
+		Addresses:  []string{alice},
+		Currencies: []txevent.Currency{txevent.TRON_USDT},
+	}})
+
+	assert.NoError(t, sink.Record(ctx, event("aa", txevent.TRON_USDT, bob, alice)))
+	// recorded twice, delivered once
+	assert.NoError(t, sink.Record(ctx, event("aa", txevent.TRON_USDT, bob, alice)))
+	// filtered by currency
+	assert.NoError(t, sink.Record(ctx, event("bb", txevent.TRX, bob, alice)))
+	// filtered by address
+	assert.NoError(t, sink.Record(ctx, event("cc", txevent.TRON_USDT, bob, bob)))
+
+	assert.NoError(t, sink.Flush(ctx))
+
+	assert.Len(t, recv.bodies, 1)
+	assert.NoError(t, recv.errs[0])
+	assert.Contains(t, string(recv.bodies[0]), `"aa:0"`)
+
+	due, err := outbox.Due(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestSinkStates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	recv := &receiver{secret: []byte("secret")}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	sink := New(NewMemOutbox(), []Endpoint{{URL: srv.URL, Secret: []byte("secret")}})
+
+	e := event("aa", txevent.TRX, bob, alice)
+	e.State = txevent.StatePending
+	assert.NoError(t, sink.Record(ctx, e))
+	e.State = txevent.StateConfirmed
+	assert.NoError(t, sink.Record(ctx, e))
+	assert.NoError(t, sink.Flush(ctx))
+
+	// each state is a delivery of its own
+	assert.ElementsMatch(t, []string{"aa:0:PENDING", "aa:0:CONFIRMED"}, recv.ids)
+	for _, b := range recv.bodies {
+		var ce map[string]any
+		assert.NoError(t, json.Unmarshal(b, &ce))
+		assert.Contains(t, recv.ids, ce["id"])
+	}
+}
+
+func TestSinkRetries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	recv := &receiver{secret: []byte("secret"), fail: 2}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	now := time.Unix(1_700_000_000, 0)
+	outbox := NewMemOutbox()
+	sink := New(outbox, []Endpoint{{URL: srv.URL, Secret: []byte("secret")}},
+		WithRetries(3, time.Second, time.Minute))
+	sink.now = func() time.Time { return now }
+
+	assert.NoError(t, sink.Record(ctx, event("aa", txevent.TRX, bob, alice)))
+
+	assert.NoError(t, sink.Flush(ctx))
+	due, err := outbox.Due(now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, 1, due[0].Attempts)
+	assert.Equal(t, now.Add(time.Second), due[0].NextAttempt)
+
+	// not due yet
+	assert.NoError(t, sink.Flush(ctx))
+	assert.Equal(t, 1, recv.fail)
+
+	now = now.Add(time.Second)
+	assert.NoError(t, sink.Flush(ctx))
+	due, err = outbox.Due(now.Add(2 * time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, due[0].Attempts)
+	assert.Equal(t, now.Add(2*time.Second), due[0].NextAttempt)
+
+	now = now.Add(2 * time.Second)
+	assert.NoError(t, sink.Flush(ctx))
+	assert.Len(t, recv.bodies, 1)
+}
+
+func TestSinkDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	recv := &receiver{fail: 100}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	outbox, err := NewFileOutbox(t.TempDir())
+	assert.NoError(t, err)
+	sink := New(outbox, []Endpoint{{URL: srv.URL}}, WithRetries(2, 0, 0))
+
+	assert.NoError(t, sink.Record(ctx, event("aa", txevent.TRX, bob, alice)))
+	assert.NoError(t, sink.Flush(ctx))
+	assert.NoError(t, sink.Flush(ctx))
+
+	due, err := outbox.Due(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+
+	dead, err := outbox.DeadLetters()
+	assert.NoError(t, err)
+	assert.Len(t, dead, 1)
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Equal(t, "aa", dead[0].Event.Hash)
+	assert.Contains(t, dead[0].LastError, "503")
+
+	// dead letters are not recorded again
+	assert.NoError(t, sink.Record(ctx, event("aa", txevent.TRX, bob, alice)))
+	due, err = outbox.Due(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	body := []byte(`{"id":"aa:0"}`)
+	timestamp := "1700000000"
+
+	header := http.Header{}
+	header.Set(TimestampHeader, timestamp)
+	header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, body))
+
+	assert.ErrorIs(t, Verify(secret, header, body, time.Minute), ErrInvalidSignature)
+	assert.NoError(t, Verify(secret, header, body, time.Since(time.Unix(1_700_000_000, 0))+time.Minute))
+	assert.ErrorIs(t, Verify([]byte("other"), header, body, 100*365*24*time.Hour), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, header, []byte(`{}`), 100*365*24*time.Hour), ErrInvalidSignature)
+}